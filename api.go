@@ -0,0 +1,33 @@
+package bgpview
+
+import (
+	"context"
+	"io"
+	"net/netip"
+)
+
+// API is the set of the BGPView API methods implemented by Client.
+// It allows to replace the Client by a mock.
+type API interface {
+	GetASN(ctx context.Context, asNumber int) (*ASNInfo, error)
+	GetASNPrefixes(ctx context.Context, asNumber int) (*ASNPrefixesInfo, error)
+	GetASNPeers(ctx context.Context, asNumber int) (*ASNPeersInfo, error)
+	GetASNUpstreams(ctx context.Context, asNumber int) (*ASNUpstreamsInfo, error)
+	GetASNDownstreams(ctx context.Context, asNumber int) (*ASNDownstreamsInfo, error)
+	GetASNIxs(ctx context.Context, asNumber int) (*ASNIxsInfo, error)
+	GetPrefix(ctx context.Context, ipAddress string, cidr int) (*PrefixInfo, error)
+	GetIP(ctx context.Context, ipAddress string) (*IPInfo, error)
+	GetNetPrefix(ctx context.Context, prefix netip.Prefix) (*PrefixInfo, error)
+	GetNetIP(ctx context.Context, ipAddress netip.Addr) (*IPInfo, error)
+	GetIX(ctx context.Context, ixID int) (*IXInfo, error)
+	GetSearch(ctx context.Context, term string) (*SearchInfo, error)
+	Lookup(ctx context.Context, term string) (*LookupResult, error)
+
+	GetASNs(ctx context.Context, asNumbers []int) (map[int]*ASNInfo, error)
+	GetIPs(ctx context.Context, ipAddresses []string) (map[string]*IPInfo, error)
+	GetPrefixes(ctx context.Context, prefixes []netip.Prefix) (map[netip.Prefix]*PrefixInfo, error)
+
+	Enrich(ctx context.Context, r io.Reader, fn func(e *Enrichment) error) error
+}
+
+var _ API = (*Client)(nil)
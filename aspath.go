@@ -0,0 +1,246 @@
+package bgpview
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ASPathSegment is a segment of an AS path: an ordered sequence of ASNs, or an unordered AS_SET.
+type ASPathSegment struct {
+	ASNs []int
+	// Set is true for an AS_SET (e.g. "{64496,64497}").
+	Set bool
+}
+
+// ASPath is a BGP AS path (e.g. "61138 6939 {64496,64497}"), from the neighbor to the origin.
+type ASPath []ASPathSegment
+
+// BGPPath is an AS path as returned by the API (e.g. "61138 6939 {64496,64497}").
+// It is kept as is, so that a path that can't be parsed doesn't fail the decoding of the response.
+type BGPPath string
+
+// ASPath parses the path.
+func (p BGPPath) ASPath() (ASPath, error) {
+	return ParseASPath(string(p))
+}
+
+// ParseASPath parses an AS path: ASNs separated by spaces, AS_SETs between braces.
+// The ASNs may have an "AS" prefix.
+func ParseASPath(s string) (ASPath, error) {
+	var path ASPath
+
+	rest := strings.TrimSpace(s)
+
+	for rest != "" {
+		if strings.HasPrefix(rest, "{") {
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid AS path %q: unterminated AS_SET", s)
+			}
+
+			asns, err := parseASNs(s, strings.FieldsFunc(rest[1:end], isASSetSeparator))
+			if err != nil {
+				return nil, err
+			}
+
+			if len(asns) == 0 {
+				return nil, fmt.Errorf("invalid AS path %q: empty AS_SET", s)
+			}
+
+			path = append(path, ASPathSegment{ASNs: asns, Set: true})
+			rest = strings.TrimSpace(rest[end+1:])
+
+			continue
+		}
+
+		end := strings.IndexByte(rest, '{')
+		if end < 0 {
+			end = len(rest)
+		}
+
+		asns, err := parseASNs(s, strings.Fields(rest[:end]))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(path) > 0 && !path[len(path)-1].Set {
+			path[len(path)-1].ASNs = append(path[len(path)-1].ASNs, asns...)
+		} else {
+			path = append(path, ASPathSegment{ASNs: asns})
+		}
+
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	return path, nil
+}
+
+// Len returns the length of the path used by the BGP best path selection:
+// each ASN of a sequence counts for 1, each AS_SET counts for 1.
+func (p ASPath) Len() int {
+	length := 0
+
+	for _, segment := range p {
+		if segment.Set {
+			length++
+			continue
+		}
+
+		length += len(segment.ASNs)
+	}
+
+	return length
+}
+
+// ASNs returns all the ASNs of the path, in order.
+func (p ASPath) ASNs() []int {
+	var asns []int
+	for _, segment := range p {
+		asns = append(asns, segment.ASNs...)
+	}
+
+	return asns
+}
+
+// Deprepend returns the path without the consecutive repetitions of an ASN (prepending).
+func (p ASPath) Deprepend() ASPath {
+	result := make(ASPath, 0, len(p))
+	last := -1
+
+	for _, segment := range p {
+		if segment.Set {
+			result = append(result, ASPathSegment{ASNs: append([]int(nil), segment.ASNs...), Set: true})
+			last = -1
+
+			continue
+		}
+
+		var asns []int
+
+		for _, asn := range segment.ASNs {
+			if asn != last {
+				asns = append(asns, asn)
+			}
+
+			last = asn
+		}
+
+		if len(asns) > 0 {
+			result = append(result, ASPathSegment{ASNs: asns})
+		}
+	}
+
+	return result
+}
+
+// Neighbor returns the first ASN of the path.
+// It returns false if the path is empty or starts with an AS_SET.
+func (p ASPath) Neighbor() (int, bool) {
+	if len(p) == 0 || p[0].Set || len(p[0].ASNs) == 0 {
+		return 0, false
+	}
+
+	return p[0].ASNs[0], true
+}
+
+// Origin returns the ASN originating the route, the last ASN of the path.
+// It returns false if the path is empty or ends with an AS_SET (aggregated route).
+func (p ASPath) Origin() (int, bool) {
+	if len(p) == 0 {
+		return 0, false
+	}
+
+	last := p[len(p)-1]
+	if last.Set || len(last.ASNs) == 0 {
+		return 0, false
+	}
+
+	return last.ASNs[len(last.ASNs)-1], true
+}
+
+// HasLoop reports whether an ASN appears several times in the path, excluding prepending.
+func (p ASPath) HasLoop() bool {
+	seen := make(map[int]struct{})
+
+	for _, segment := range p.Deprepend() {
+		for _, asn := range segment.ASNs {
+			if _, ok := seen[asn]; ok {
+				return true
+			}
+
+			seen[asn] = struct{}{}
+		}
+	}
+
+	return false
+}
+
+func (p ASPath) String() string {
+	parts := make([]string, 0, len(p))
+
+	for _, segment := range p {
+		asns := make([]string, len(segment.ASNs))
+		for i, asn := range segment.ASNs {
+			asns[i] = strconv.Itoa(asn)
+		}
+
+		if segment.Set {
+			parts = append(parts, "{"+strings.Join(asns, ",")+"}")
+			continue
+		}
+
+		parts = append(parts, strings.Join(asns, " "))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ASPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ASPath) UnmarshalJSON(data []byte) error {
+	var value string
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("invalid AS path %s: %w", data, err)
+	}
+
+	path, err := ParseASPath(value)
+	if err != nil {
+		return err
+	}
+
+	*p = path
+
+	return nil
+}
+
+func isASSetSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
+
+func parseASNs(s string, fields []string) ([]int, error) {
+	asns := make([]int, 0, len(fields))
+
+	for _, field := range fields {
+		trimmed := field
+		if len(trimmed) > 2 && strings.EqualFold(trimmed[:2], "AS") {
+			trimmed = trimmed[2:]
+		}
+
+		asn, err := strconv.ParseUint(trimmed, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AS path %q: invalid ASN %q", s, field)
+		}
+
+		asns = append(asns, int(asn))
+	}
+
+	return asns, nil
+}
@@ -0,0 +1,179 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseASPath(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected ASPath
+	}{
+		{
+			value:    "6939 137409 61138",
+			expected: ASPath{{ASNs: []int{6939, 137409, 61138}}},
+		},
+		{
+			value:    "AS6939  as61138",
+			expected: ASPath{{ASNs: []int{6939, 61138}}},
+		},
+		{
+			value: "137409 {64496,64497} 61138",
+			expected: ASPath{
+				{ASNs: []int{137409}},
+				{ASNs: []int{64496, 64497}, Set: true},
+				{ASNs: []int{61138}},
+			},
+		},
+		{
+			value:    "{64496, 64497}",
+			expected: ASPath{{ASNs: []int{64496, 64497}, Set: true}},
+		},
+		{
+			value: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := ParseASPath(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, path)
+		})
+	}
+}
+
+func TestParseASPath_invalid(t *testing.T) {
+	for _, value := range []string{"6939 x", "6939 {64496", "6939 {}", "4294967296", "-1"} {
+		_, err := ParseASPath(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestASPath(t *testing.T) {
+	testCases := []struct {
+		value     string
+		length    int
+		deprepend string
+		neighbor  int
+		origin    int
+		loop      bool
+	}{
+		{value: "6939 137409 61138", length: 3, deprepend: "6939 137409 61138", neighbor: 6939, origin: 61138},
+		{value: "6939 137409 61138 61138 61138", length: 5, deprepend: "6939 137409 61138", neighbor: 6939, origin: 61138},
+		{value: "6939 137409 6939 61138", length: 4, deprepend: "6939 137409 6939 61138", neighbor: 6939, origin: 61138, loop: true},
+		{value: "137409 {64496,64497}", length: 2, deprepend: "137409 {64496,64497}", neighbor: 137409},
+		{value: "{64496,64497} 61138", length: 2, deprepend: "{64496,64497} 61138", origin: 61138},
+		{value: "61138 61138", length: 2, deprepend: "61138", neighbor: 61138, origin: 61138},
+		{value: "", deprepend: ""},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			path, err := ParseASPath(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.value, path.String())
+			assert.Equal(t, test.length, path.Len())
+			assert.Equal(t, test.deprepend, path.Deprepend().String())
+			assert.Equal(t, test.loop, path.HasLoop())
+
+			neighbor, ok := path.Neighbor()
+			assert.Equal(t, test.neighbor, neighbor)
+			assert.Equal(t, test.neighbor != 0, ok)
+
+			origin, ok := path.Origin()
+			assert.Equal(t, test.origin, origin)
+			assert.Equal(t, test.origin != 0, ok)
+		})
+	}
+}
+
+func TestASPath_JSON(t *testing.T) {
+	var paths []ASPath
+
+	err := json.Unmarshal([]byte(`["137409 61138", "137409 {64496, 64497}"]`), &paths)
+	require.NoError(t, err)
+
+	expected := []ASPath{
+		{{ASNs: []int{137409, 61138}}},
+		{{ASNs: []int{137409}}, {ASNs: []int{64496, 64497}, Set: true}},
+	}
+	assert.Equal(t, expected, paths)
+
+	raw, err := json.Marshal(paths)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `["137409 61138", "137409 {64496,64497}"]`, string(raw))
+
+	err = json.Unmarshal([]byte(`["137409 x"]`), &paths)
+	require.Error(t, err)
+}
+
+func TestBGPPath(t *testing.T) {
+	var data ASNIPUpstreamsData
+
+	// An invalid path doesn't fail the decoding.
+	err := json.Unmarshal([]byte(`{"asn": 137409, "bgp_paths": ["137409 61138", "137409 x"]}`), &data)
+	require.NoError(t, err)
+
+	require.Len(t, data.BgpPaths, 2)
+
+	path, err := data.BgpPaths[0].ASPath()
+	require.NoError(t, err)
+
+	assert.Equal(t, ASPath{{ASNs: []int{137409, 61138}}}, path)
+
+	_, err = data.BgpPaths[1].ASPath()
+	require.EqualError(t, err, `invalid AS path "137409 x": invalid ASN "x"`)
+
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"asn": 137409, "bgp_paths": ["137409 61138", "137409 x"]}`, string(raw))
+}
+
+func TestClient_GetASNUpstreams_bgpPaths(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138/upstreams", testHandler("asn-upstreams-paths.json"))
+
+	upstreams, err := client.GetASNUpstreams(context.Background(), 61138)
+	require.NoError(t, err)
+
+	bgpPaths := upstreams.Data.IPv4Upstreams[0].BgpPaths
+	require.Len(t, bgpPaths, 4)
+
+	paths := make([]ASPath, len(bgpPaths))
+	for i, bgpPath := range bgpPaths {
+		paths[i], err = bgpPath.ASPath()
+		require.NoError(t, err)
+	}
+
+	for _, path := range paths[:3] {
+		origin, ok := path.Origin()
+		assert.True(t, ok)
+		assert.Equal(t, 61138, origin)
+	}
+
+	assert.Equal(t, "6939 137409 61138", paths[1].Deprepend().String())
+	assert.True(t, paths[2].HasLoop())
+
+	_, ok := paths[3].Origin()
+	assert.False(t, ok)
+
+	assert.Empty(t, upstreams.Data.IPv4Upstreams[1].BgpPaths)
+	assert.Equal(t, BGPPath("137409 61138"), upstreams.Data.IPv6Upstreams[0].BgpPaths[0])
+}
@@ -0,0 +1,188 @@
+package bgpview
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Bandwidth is a rate in bits per second.
+type Bandwidth uint64
+
+// Bandwidth units.
+const (
+	BitPerSecond  Bandwidth = 1
+	KbitPerSecond           = 1000 * BitPerSecond
+	MbitPerSecond           = 1000 * KbitPerSecond
+	GbitPerSecond           = 1000 * MbitPerSecond
+	TbitPerSecond           = 1000 * GbitPerSecond
+)
+
+var bandwidthUnits = []struct {
+	suffix string
+	unit   Bandwidth
+}{
+	{suffix: "tbps", unit: TbitPerSecond},
+	{suffix: "gbps", unit: GbitPerSecond},
+	{suffix: "mbps", unit: MbitPerSecond},
+	{suffix: "kbps", unit: KbitPerSecond},
+	{suffix: "bps", unit: BitPerSecond},
+	{suffix: "t", unit: TbitPerSecond},
+	{suffix: "g", unit: GbitPerSecond},
+	{suffix: "m", unit: MbitPerSecond},
+	{suffix: "k", unit: KbitPerSecond},
+}
+
+// ParseBandwidth parses a bandwidth (e.g. "100Mbps", "1.5 Gbps", "10G").
+// The unit is case-insensitive, a value without unit is in bits per second.
+func ParseBandwidth(s string) (Bandwidth, error) {
+	value, unit := splitBandwidthUnit(s)
+	if unit == 0 {
+		unit = BitPerSecond
+	}
+
+	return parseBandwidthValue(s, value, unit)
+}
+
+// String returns the bandwidth in the largest fitting unit (e.g. "1.5Gbps").
+func (b Bandwidth) String() string {
+	for _, u := range []struct {
+		suffix string
+		unit   Bandwidth
+	}{
+		{suffix: "Tbps", unit: TbitPerSecond},
+		{suffix: "Gbps", unit: GbitPerSecond},
+		{suffix: "Mbps", unit: MbitPerSecond},
+		{suffix: "Kbps", unit: KbitPerSecond},
+	} {
+		if b >= u.unit {
+			return strconv.FormatFloat(float64(b)/float64(u.unit), 'f', -1, 64) + u.suffix
+		}
+	}
+
+	return strconv.FormatUint(uint64(b), 10) + "bps"
+}
+
+// TrafficRange is an estimated traffic range.
+type TrafficRange struct {
+	Min Bandwidth
+	// Max is 0 when the range has no upper bound (e.g. "100+Tbps").
+	Max Bandwidth
+}
+
+// ParseTrafficRange parses a traffic estimation (e.g. "1-5Gbps", "100-1000Mbps", "100+Tbps").
+// A single value (e.g. "10Gbps") is a range with the same min and max.
+func ParseTrafficRange(s string) (TrafficRange, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return TrafficRange{}, errors.New("empty traffic range")
+	}
+
+	rangeValues, unit := splitBandwidthUnit(trimmed)
+	if unit == 0 {
+		return TrafficRange{}, fmt.Errorf("invalid traffic range %q: missing unit", s)
+	}
+
+	if strings.HasSuffix(rangeValues, "+") {
+		minimum, err := parseBandwidthValue(s, strings.TrimSuffix(rangeValues, "+"), unit)
+		if err != nil {
+			return TrafficRange{}, err
+		}
+
+		return TrafficRange{Min: minimum}, nil
+	}
+
+	low, high, ok := strings.Cut(rangeValues, "-")
+	if !ok {
+		high = low
+	}
+
+	minimum, err := parseBandwidthValue(s, low, unit)
+	if err != nil {
+		return TrafficRange{}, err
+	}
+
+	maximum, err := parseBandwidthValue(s, high, unit)
+	if err != nil {
+		return TrafficRange{}, err
+	}
+
+	if maximum < minimum {
+		return TrafficRange{}, fmt.Errorf("invalid traffic range %q: max lower than min", s)
+	}
+
+	return TrafficRange{Min: minimum, Max: maximum}, nil
+}
+
+// IsZero reports whether the range is unknown.
+func (r TrafficRange) IsZero() bool {
+	return r == TrafficRange{}
+}
+
+// Unbounded reports whether the range has no upper bound.
+func (r TrafficRange) Unbounded() bool {
+	return r.Min > 0 && r.Max == 0
+}
+
+func (r TrafficRange) String() string {
+	switch {
+	case r.IsZero():
+		return ""
+	case r.Unbounded():
+		return r.Min.String() + "+"
+	default:
+		return r.Min.String() + "-" + r.Max.String()
+	}
+}
+
+// Traffic returns the parsed traffic estimation, the zero value if it's empty or not a range (e.g. "Not Disclosed").
+func (d ASNData) Traffic() TrafficRange {
+	r, err := ParseTrafficRange(d.TrafficEstimation)
+	if err != nil {
+		return TrafficRange{}
+	}
+
+	return r
+}
+
+// PortSpeed returns the port speed (the Speed field is in Mbps).
+func (d MemberData) PortSpeed() Bandwidth {
+	return Bandwidth(d.Speed) * MbitPerSecond
+}
+
+// PortSpeed returns the port speed (the Speed field is in Mbps).
+func (d ASNIxsData) PortSpeed() Bandwidth {
+	return Bandwidth(d.Speed) * MbitPerSecond
+}
+
+// splitBandwidthUnit splits a value and its unit suffix, the unit is 0 without suffix.
+func splitBandwidthUnit(s string) (string, Bandwidth) {
+	trimmed := strings.TrimSpace(s)
+	lower := strings.ToLower(trimmed)
+
+	for _, u := range bandwidthUnits {
+		if strings.HasSuffix(lower, u.suffix) {
+			return strings.TrimSpace(trimmed[:len(trimmed)-len(u.suffix)]), u.unit
+		}
+	}
+
+	return trimmed, 0
+}
+
+func parseBandwidthValue(s, value string, unit Bandwidth) (Bandwidth, error) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || number < 0 || math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, fmt.Errorf("invalid bandwidth %q", s)
+	}
+
+	bits := math.Round(number * float64(unit))
+
+	// float64(math.MaxUint64) is 2^64, out of range.
+	if bits >= float64(math.MaxUint64) {
+		return 0, fmt.Errorf("invalid bandwidth %q: out of range", s)
+	}
+
+	return Bandwidth(bits), nil
+}
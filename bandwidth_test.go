@@ -0,0 +1,126 @@
+package bgpview
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected Bandwidth
+	}{
+		{value: "100Mbps", expected: 100 * MbitPerSecond},
+		{value: "1.5 Gbps", expected: 1500 * MbitPerSecond},
+		{value: "10G", expected: 10 * GbitPerSecond},
+		{value: "2tbps", expected: 2 * TbitPerSecond},
+		{value: "64 kbps", expected: 64 * KbitPerSecond},
+		{value: "512", expected: 512 * BitPerSecond},
+		{value: "512bps", expected: 512 * BitPerSecond},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			bandwidth, err := ParseBandwidth(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, bandwidth)
+		})
+	}
+}
+
+func TestParseBandwidth_invalid(t *testing.T) {
+	for _, value := range []string{"", "Gbps", "fast", "-1Gbps", "NaN"} {
+		_, err := ParseBandwidth(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestBandwidth_String(t *testing.T) {
+	assert.Equal(t, "0bps", Bandwidth(0).String())
+	assert.Equal(t, "999bps", Bandwidth(999).String())
+	assert.Equal(t, "100Mbps", (100 * MbitPerSecond).String())
+	assert.Equal(t, "1.5Gbps", (1500 * MbitPerSecond).String())
+	assert.Equal(t, "100Tbps", (100 * TbitPerSecond).String())
+}
+
+func TestParseTrafficRange(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected TrafficRange
+	}{
+		{value: "1-5Gbps", expected: TrafficRange{Min: GbitPerSecond, Max: 5 * GbitPerSecond}},
+		{value: "0-20Mbps", expected: TrafficRange{Min: 0, Max: 20 * MbitPerSecond}},
+		{value: "100-1000Mbps", expected: TrafficRange{Min: 100 * MbitPerSecond, Max: GbitPerSecond}},
+		{value: "100+Tbps", expected: TrafficRange{Min: 100 * TbitPerSecond}},
+		{value: "10Gbps", expected: TrafficRange{Min: 10 * GbitPerSecond, Max: 10 * GbitPerSecond}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := ParseTrafficRange(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, r)
+		})
+	}
+}
+
+func TestParseTrafficRange_invalid(t *testing.T) {
+	for _, value := range []string{"", "Not Disclosed", "1-5", "5-1Gbps", "a-bGbps", "1e30Tbps", "1-1e30Tbps", "18446744073709551616bps"} {
+		_, err := ParseTrafficRange(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTrafficRange_String(t *testing.T) {
+	assert.Equal(t, "", TrafficRange{}.String())
+	assert.Equal(t, "1Gbps-5Gbps", TrafficRange{Min: GbitPerSecond, Max: 5 * GbitPerSecond}.String())
+	assert.Equal(t, "100Tbps+", TrafficRange{Min: 100 * TbitPerSecond}.String())
+	assert.True(t, TrafficRange{Min: 100 * TbitPerSecond}.Unbounded())
+}
+
+func TestASNData_Traffic(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138", testHandler("asn.json"))
+
+	asn, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, TrafficRange{Min: GbitPerSecond, Max: 5 * GbitPerSecond}, asn.Data.Traffic())
+
+	assert.True(t, ASNData{TrafficEstimation: "Not Disclosed"}.Traffic().IsZero())
+}
+
+func TestASNIxsData_PortSpeed(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138/ixs", testHandler("asn-ixs.json"))
+
+	ixs, err := client.GetASNIxs(context.Background(), 61138)
+	require.NoError(t, err)
+
+	var total Bandwidth
+	for _, ix := range ixs.Data {
+		total += ix.PortSpeed()
+	}
+
+	assert.Equal(t, Bandwidth(len(ixs.Data))*100*MbitPerSecond, total)
+
+	members := []MemberData{{ASN: 1, Speed: 10000}, {ASN: 2, Speed: 1000}, {ASN: 3, Speed: 100000}}
+	sort.Slice(members, func(i, j int) bool { return members[i].PortSpeed() > members[j].PortSpeed() })
+
+	assert.Equal(t, 3, members[0].ASN)
+	assert.Equal(t, "100Gbps", members[0].PortSpeed().String())
+}
@@ -0,0 +1,191 @@
+package bgpview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// BatchError is returned by the batch methods when some lookups failed.
+type BatchError[K comparable] struct {
+	// Errors are the errors by item.
+	Errors map[K]error
+}
+
+func (e *BatchError[K]) Error() string {
+	keys := e.keys()
+	if len(keys) == 0 {
+		return "bgpview: no lookup failed"
+	}
+
+	key := keys[0]
+
+	if len(keys) == 1 {
+		return fmt.Sprintf("bgpview: lookup of %v failed: %v", key, e.Errors[key])
+	}
+
+	return fmt.Sprintf("bgpview: %d lookups failed, including %v: %v", len(keys), key, e.Errors[key])
+}
+
+// Unwrap returns the errors of the failed lookups, ordered by item (numerically for the integer items).
+func (e *BatchError[K]) Unwrap() []error {
+	keys := e.keys()
+
+	errs := make([]error, 0, len(keys))
+	for _, key := range keys {
+		errs = append(errs, e.Errors[key])
+	}
+
+	return errs
+}
+
+// Is reports whether the error of a failed lookup matches the target.
+// It makes errors.Is walk the errors before Go 1.20, which doesn't use Unwrap() []error.
+func (e *BatchError[K]) Is(target error) bool {
+	for _, err := range e.Unwrap() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error of the failed lookups, ordered as by Unwrap, matching the target.
+// It makes errors.As walk the errors before Go 1.20, which doesn't use Unwrap() []error.
+func (e *BatchError[K]) As(target interface{}) bool {
+	for _, err := range e.Unwrap() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keys returns the failed items, ordered numerically for the integer items
+// (e.g. ASNs), by their string representation otherwise.
+func (e *BatchError[K]) keys() []K {
+	keys := make([]K, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+
+	return keys
+}
+
+func lessKey(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() < vb.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() < vb.Uint()
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+// GetASNs gets several ASNs concurrently.
+// The results of the successful lookups are returned even if some lookups failed, with a *BatchError.
+func (c Client) GetASNs(ctx context.Context, asNumbers []int) (map[int]*ASNInfo, error) {
+	return batch(ctx, asNumbers, c.BatchConcurrency, c.GetASN)
+}
+
+// GetIPs gets several IPs concurrently.
+// The results of the successful lookups are returned even if some lookups failed, with a *BatchError.
+func (c Client) GetIPs(ctx context.Context, ipAddresses []string) (map[string]*IPInfo, error) {
+	return batch(ctx, ipAddresses, c.BatchConcurrency, c.GetIP)
+}
+
+// GetPrefixes gets several prefixes concurrently.
+// The results of the successful lookups are returned even if some lookups failed, with a *BatchError.
+func (c Client) GetPrefixes(ctx context.Context, prefixes []netip.Prefix) (map[netip.Prefix]*PrefixInfo, error) {
+	return batch(ctx, prefixes, c.BatchConcurrency, c.GetNetPrefix)
+}
+
+// batch runs the lookups with a bounded number of workers.
+// When the context is done, the remaining items are not dispatched and fail with the context error.
+func batch[K comparable, V any](ctx context.Context, keys []K, workers int, lookup func(context.Context, K) (V, error)) (map[K]V, error) {
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+
+	var mu sync.Mutex
+
+	results := make(map[K]V)
+	errs := make(map[K]error)
+
+	record := func(key K, value V, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs[key] = err
+			return
+		}
+
+		results[key] = value
+	}
+
+	jobs := make(chan K)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for key := range jobs {
+				value, err := lookup(ctx, key)
+				record(key, value, err)
+			}
+		}()
+	}
+
+	seen := make(map[K]struct{}, len(keys))
+
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		if ctx.Err() != nil {
+			var zero V
+			record(key, zero, ctx.Err())
+
+			continue
+		}
+
+		select {
+		case jobs <- key:
+		case <-ctx.Done():
+			var zero V
+			record(key, zero, ctx.Err())
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, &BatchError[K]{Errors: errs}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,144 @@
+package bgpview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetASNs(t *testing.T) {
+	client, mux := setupTest(t)
+	client.BatchConcurrency = 2
+
+	var inFlight, maxInFlight int32
+
+	mux.HandleFunc("/asn/", func(rw http.ResponseWriter, req *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			highest := atomic.LoadInt32(&maxInFlight)
+			if current <= highest || atomic.CompareAndSwapInt32(&maxInFlight, highest, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		if req.URL.Path == "/asn/1" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		testHandler("asn.json")(rw, req)
+	})
+
+	results, err := client.GetASNs(context.Background(), []int{61138, 1, 61139, 61140, 61138})
+	require.Error(t, err)
+
+	var batchErr *BatchError[int]
+	require.True(t, errors.As(err, &batchErr))
+	require.Len(t, batchErr.Errors, 1)
+	assert.ErrorIs(t, batchErr.Errors[1], ErrNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Len(t, results, 3)
+	assert.Contains(t, results, 61138)
+	assert.Contains(t, results, 61139)
+	assert.Contains(t, results, 61140)
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestClient_GetIPs(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip.json"))
+
+	results, err := client.GetIPs(context.Background(), []string{"2a05:dfc7:60::"})
+	require.NoError(t, err)
+
+	require.Contains(t, results, "2a05:dfc7:60::")
+	assert.Equal(t, "US-ZAPPIE-20150303", results["2a05:dfc7:60::"].Data.Prefixes[0].Name)
+}
+
+func TestClient_GetPrefixes(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/prefix/192.209.63.0/24", testHandler("prefix.json"))
+
+	prefix := netip.MustParsePrefix("192.209.63.0/24")
+
+	results, err := client.GetPrefixes(context.Background(), []netip.Prefix{prefix})
+	require.NoError(t, err)
+
+	require.Contains(t, results, prefix)
+	assert.Equal(t, "BITACCEL-NETWORK", results[prefix].Data.Name)
+}
+
+func TestClient_GetASNs_canceled(t *testing.T) {
+	client, mux := setupTest(t)
+	client.BatchConcurrency = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux.HandleFunc("/asn/1", testHandler("asn.json"))
+	mux.HandleFunc("/asn/2", func(_ http.ResponseWriter, req *http.Request) {
+		cancel()
+		<-req.Context().Done()
+	})
+
+	results, err := client.GetASNs(ctx, []int{1, 2, 3, 4})
+	require.Error(t, err)
+
+	var batchErr *BatchError[int]
+	require.True(t, errors.As(err, &batchErr))
+
+	assert.Len(t, results, 1)
+	assert.Contains(t, results, 1)
+	assert.Len(t, batchErr.Errors, 3)
+
+	for _, itemErr := range batchErr.Errors {
+		assert.ErrorIs(t, itemErr, context.Canceled)
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	apiErr := &APIError{HTTPStatus: http.StatusTooManyRequests}
+
+	err := error(&BatchError[string]{Errors: map[string]error{
+		"c": context.Canceled,
+		"a": fmt.Errorf("wrapped: %w", apiErr),
+		"b": ErrNotFound,
+	}})
+
+	// The first item is reported, whatever the map order.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "bgpview: 3 lookups failed, including a: wrapped: "+apiErr.Error(), err.Error())
+	}
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+
+	var target *APIError
+	require.True(t, errors.As(err, &target))
+	assert.Same(t, apiErr, target)
+
+	single := &BatchError[int]{Errors: map[int]error{1: ErrNotFound}}
+	assert.Equal(t, "bgpview: lookup of 1 failed: "+ErrNotFound.Error(), single.Error())
+
+	// The integer items are ordered numerically.
+	asns := &BatchError[int]{Errors: map[int]error{10: ErrServer, 9: ErrNotFound, 100: ErrRateLimited}}
+	assert.Equal(t, "bgpview: 3 lookups failed, including 9: "+ErrNotFound.Error(), asns.Error())
+	assert.Equal(t, []error{ErrNotFound, ErrServer, ErrRateLimited}, asns.Unwrap())
+}
@@ -0,0 +1,223 @@
+// Package bgpviewtest provides a fake BGPView API server for tests.
+package bgpviewtest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Routes of the fixtures loaded by default.
+const (
+	RouteASN            = "/asn/61138"
+	RouteASNPrefixes    = "/asn/61138/prefixes"
+	RouteASNPeers       = "/asn/61138/peers"
+	RouteASNUpstreams   = "/asn/61138/upstreams"
+	RouteASNDownstreams = "/asn/61138/downstreams"
+	RouteASNIxs         = "/asn/61138/ixs"
+	RoutePrefix         = "/prefix/192.209.63.0/24"
+	RouteIP             = "/ip/2a05:dfc7:60::"
+	RouteIX             = "/ix/492"
+	RouteSearch         = "/search?query_term=digitalocean"
+)
+
+var defaultRoutes = map[string]string{
+	RouteASN:            "asn.json",
+	RouteASNPrefixes:    "asn-prefixes.json",
+	RouteASNPeers:       "asn-peers.json",
+	RouteASNUpstreams:   "asn-upstreams.json",
+	RouteASNDownstreams: "asn-downstreams.json",
+	RouteASNIxs:         "asn-ixs.json",
+	RoutePrefix:         "prefix.json",
+	RouteIP:             "ip.json",
+	RouteIX:             "ix.json",
+	RouteSearch:         "search.json",
+}
+
+// Fixture returns the content of a fixture (e.g. "asn.json").
+// It panics if the fixture doesn't exist.
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile(path.Join("fixtures", name))
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// Response is a canned response of the Server.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Latency delays the response.
+	Latency time.Duration
+}
+
+// Server is a fake BGPView API server.
+//
+// A route is a request path (e.g. "/asn/61138"), optionally followed by a query (e.g. "/search?query_term=test").
+// A route with a query is matched before the route of the path alone.
+// Unknown routes respond with a BGPView-like 404 error.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string]http.Handler
+	latency  map[string]time.Duration
+	requests map[string]int
+}
+
+// NewServer starts a Server pre-loaded with the fixtures, closed at the end of the test.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	s := &Server{
+		routes:   make(map[string]http.Handler),
+		latency:  make(map[string]time.Duration),
+		requests: make(map[string]int),
+	}
+
+	for route, name := range defaultRoutes {
+		s.SetFixture(route, name)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Handle registers a custom handler for a route.
+func (s *Server) Handle(route string, handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes[route] = handler
+}
+
+// SetResponse registers a canned response for a route.
+func (s *Server) SetResponse(route string, resp Response) {
+	s.Handle(route, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if resp.Latency > 0 && !wait(req, resp.Latency) {
+			return
+		}
+
+		for key, values := range resp.Header {
+			rw.Header()[key] = values
+		}
+
+		if rw.Header().Get("Content-Type") == "" {
+			rw.Header().Set("Content-Type", "application/json")
+		}
+
+		statusCode := resp.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+
+		rw.WriteHeader(statusCode)
+		_, _ = rw.Write(resp.Body)
+	}))
+}
+
+// SetFixture registers a fixture (e.g. "asn.json") as the response of a route.
+func (s *Server) SetFixture(route, name string) {
+	s.SetResponse(route, Response{StatusCode: http.StatusOK, Body: Fixture(name)})
+}
+
+// SetError registers a BGPView-like error response for a route.
+func (s *Server) SetError(route string, statusCode int, message string) {
+	s.SetResponse(route, Response{StatusCode: statusCode, Body: errorBody(message)})
+}
+
+// SetLatency delays all the responses of a route, whatever their handler.
+func (s *Server) SetLatency(route string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency[route] = latency
+}
+
+// Requests returns the number of requests received by a route.
+func (s *Server) Requests(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[route]
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	route, handler, latency := s.match(req)
+
+	if latency > 0 && !wait(req, latency) {
+		return
+	}
+
+	if handler == nil {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write(errorBody(fmt.Sprintf("Route not found: %s", route)))
+
+		return
+	}
+
+	handler.ServeHTTP(rw, req)
+}
+
+func (s *Server) match(req *http.Request) (string, http.Handler, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := []string{req.URL.Path}
+	if req.URL.RawQuery != "" {
+		candidates = []string{req.URL.Path + "?" + req.URL.Query().Encode(), req.URL.Path}
+	}
+
+	for _, route := range candidates {
+		if handler, ok := s.routes[route]; ok {
+			s.requests[route]++
+			return route, handler, s.latency[route]
+		}
+	}
+
+	s.requests[candidates[0]]++
+
+	return candidates[0], nil, s.latency[candidates[0]]
+}
+
+func wait(req *http.Request, latency time.Duration) bool {
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func errorBody(message string) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"status":         "error",
+		"status_message": message,
+		"@meta":          map[string]interface{}{"time_zone": "UTC", "api_version": 1},
+	})
+
+	return body
+}
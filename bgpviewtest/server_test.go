@@ -0,0 +1,139 @@
+package bgpviewtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/electrologue/bgpview"
+	"github.com/electrologue/bgpview/bgpviewtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupClient(t *testing.T) (*bgpview.Client, *bgpviewtest.Server) {
+	t.Helper()
+
+	server := bgpviewtest.NewServer(t)
+
+	client, err := bgpview.NewClient(bgpview.WithBaseURL(server.URL), bgpview.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	return client, server
+}
+
+func TestServer_fixtures(t *testing.T) {
+	client, server := setupClient(t)
+
+	var api bgpview.API = client
+
+	ctx := context.Background()
+
+	asn, err := api.GetASN(ctx, 61138)
+	require.NoError(t, err)
+	assert.Equal(t, "ZAPPIE-HOST-AS", asn.Data.Name)
+
+	prefixes, err := api.GetASNPrefixes(ctx, 61138)
+	require.NoError(t, err)
+	assert.NotEmpty(t, prefixes.Data.IPv4Prefixes)
+
+	peers, err := api.GetASNPeers(ctx, 61138)
+	require.NoError(t, err)
+	assert.NotEmpty(t, peers.Data.IPv6Peers)
+
+	upstreams, err := api.GetASNUpstreams(ctx, 61138)
+	require.NoError(t, err)
+	assert.NotEmpty(t, upstreams.Data.IPv4Upstreams)
+
+	downstreams, err := api.GetASNDownstreams(ctx, 61138)
+	require.NoError(t, err)
+	assert.NotEmpty(t, downstreams.Data.IPv6Downstreams)
+
+	ixs, err := api.GetASNIxs(ctx, 61138)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ixs.Data)
+
+	prefix, err := api.GetPrefix(ctx, "192.209.63.0", 24)
+	require.NoError(t, err)
+	assert.Equal(t, "BITACCEL-NETWORK", prefix.Data.Name)
+
+	ip, err := api.GetIP(ctx, "2a05:dfc7:60::")
+	require.NoError(t, err)
+	assert.NotEmpty(t, ip.Data.Prefixes)
+
+	ix, err := api.GetIX(ctx, 492)
+	require.NoError(t, err)
+	assert.Equal(t, "MIXP.me", ix.Data.Name)
+
+	search, err := api.GetSearch(ctx, "digitalocean")
+	require.NoError(t, err)
+	assert.NotEmpty(t, search.Data.ASNs)
+
+	assert.Equal(t, 1, server.Requests(bgpviewtest.RouteASN))
+	assert.Equal(t, 1, server.Requests(bgpviewtest.RouteSearch))
+}
+
+func TestServer_unknownRoute(t *testing.T) {
+	client, server := setupClient(t)
+
+	_, err := client.GetASN(context.Background(), 1)
+	require.ErrorIs(t, err, bgpview.ErrNotFound)
+
+	_, err = client.GetSearch(context.Background(), "unknown")
+	require.ErrorIs(t, err, bgpview.ErrNotFound)
+
+	assert.Equal(t, 1, server.Requests("/asn/1"))
+	assert.Equal(t, 1, server.Requests("/search?query_term=unknown"))
+}
+
+func TestServer_SetResponse(t *testing.T) {
+	client, server := setupClient(t)
+
+	server.SetResponse("/search", bgpviewtest.Response{Body: bgpviewtest.Fixture("search.json")})
+	server.SetFixture("/asn/1", "asn.json")
+
+	search, err := client.GetSearch(context.Background(), "anything")
+	require.NoError(t, err)
+	assert.NotEmpty(t, search.Data.ASNs)
+
+	asn, err := client.GetASN(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 61138, asn.Data.ASN)
+}
+
+func TestServer_SetError(t *testing.T) {
+	client, server := setupClient(t)
+
+	server.SetError(bgpviewtest.RouteASN, http.StatusTooManyRequests, "Too Many Requests")
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.ErrorIs(t, err, bgpview.ErrRateLimited)
+
+	var apiErr *bgpview.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Too Many Requests", apiErr.StatusMessage)
+}
+
+func TestServer_SetLatency(t *testing.T) {
+	client, server := setupClient(t)
+
+	server.SetLatency(bgpviewtest.RouteASN, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetASN(ctx, 61138)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_Handle(t *testing.T) {
+	client, server := setupClient(t)
+
+	server.Handle(bgpviewtest.RouteIX, http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+
+	_, err := client.GetIX(context.Background(), 492)
+	require.ErrorIs(t, err, bgpview.ErrServer)
+}
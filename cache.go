@@ -0,0 +1,294 @@
+package bgpview
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached API response.
+type CacheEntry struct {
+	// StatusCode is the HTTP status code of the response (200, or 404 for negative caching).
+	StatusCode int `json:"status_code"`
+	// Body is the raw response body.
+	Body []byte `json:"body"`
+	// ExpiresAt is the end of the freshness of the entry.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Cache stores API responses keyed by endpoint URL.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// CachePolicy defines how responses are cached.
+type CachePolicy struct {
+	// DefaultTTL is the freshness of the endpoint families without a dedicated TTL.
+	DefaultTTL time.Duration
+	// TTLs are the freshness by endpoint family (FamilyASN, FamilySearch, ...).
+	// A TTL <= 0 disables the caching of the endpoint family.
+	TTLs map[string]time.Duration
+	// StaleWhileRevalidate is the duration after expiration during which a stale response is served
+	// while it is refreshed in the background.
+	StaleWhileRevalidate time.Duration
+	// NegativeTTL is the freshness of the not found responses. A value <= 0 disables negative caching.
+	NegativeTTL time.Duration
+}
+
+// DefaultCachePolicy returns a CachePolicy suitable for the slowly changing BGPView data.
+func DefaultCachePolicy() *CachePolicy {
+	return &CachePolicy{
+		DefaultTTL: time.Hour,
+		TTLs: map[string]time.Duration{
+			FamilyASN:    24 * time.Hour,
+			FamilyPrefix: 6 * time.Hour,
+			FamilyIX:     24 * time.Hour,
+		},
+		StaleWhileRevalidate: time.Hour,
+		NegativeTTL:          5 * time.Minute,
+	}
+}
+
+func (p *CachePolicy) ttl(family string) time.Duration {
+	if ttl, ok := p.TTLs[family]; ok {
+		return ttl
+	}
+
+	return p.DefaultTTL
+}
+
+func (c Client) cachedFetch(ctx context.Context, endpoint *url.URL) ([]byte, error) {
+	if c.Cache == nil {
+		return c.sharedFetch(ctx, endpoint)
+	}
+
+	policy := c.CachePolicy
+	if policy == nil {
+		policy = DefaultCachePolicy()
+	}
+
+	key := endpoint.String()
+
+	if entry, ok := c.Cache.Get(key); ok {
+		now := time.Now()
+
+		switch {
+		case now.Before(entry.ExpiresAt):
+			return entry.result(key)
+
+		case entry.StatusCode == http.StatusOK && now.Before(entry.ExpiresAt.Add(policy.StaleWhileRevalidate)):
+			c.revalidate(endpoint, policy)
+			return entry.result(key)
+		}
+	}
+
+	body, err := c.sharedFetch(ctx, endpoint)
+
+	c.store(endpoint, policy, body, err)
+
+	return body, err
+}
+
+func (c Client) revalidate(endpoint *url.URL, policy *CachePolicy) {
+	key := endpoint.String()
+
+	if _, loaded := c.revalidating.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer c.revalidating.Delete(key)
+
+		body, err := c.sharedFetch(context.Background(), endpoint)
+
+		c.store(endpoint, policy, body, err)
+	}()
+}
+
+func (c Client) store(endpoint *url.URL, policy *CachePolicy, body []byte, err error) {
+	key := endpoint.String()
+
+	if err == nil {
+		ttl := policy.ttl(endpointFamily(c.baseURL, endpoint))
+		if ttl > 0 {
+			c.Cache.Set(key, &CacheEntry{StatusCode: http.StatusOK, Body: body, ExpiresAt: time.Now().Add(ttl)})
+		}
+
+		return
+	}
+
+	var apiErr *APIError
+	if policy.NegativeTTL > 0 && errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusNotFound {
+		c.Cache.Set(key, &CacheEntry{StatusCode: http.StatusNotFound, Body: apiErr.Body, ExpiresAt: time.Now().Add(policy.NegativeTTL)})
+	}
+}
+
+func (e *CacheEntry) result(key string) ([]byte, error) {
+	if e.StatusCode != http.StatusOK {
+		return nil, newAPIError(key, e.StatusCode, e.Body)
+	}
+
+	return e.Body, nil
+}
+
+// MemoryCache is an in-memory LRU Cache.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type memoryItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates a new MemoryCache holding at most capacity entries.
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &MemoryCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get gets an entry.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(elem)
+
+	return elem.Value.(*memoryItem).entry, true
+}
+
+// Set sets an entry, evicting the least recently used entry if the cache is full.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		elem.Value.(*memoryItem).entry = entry
+		m.order.MoveToFront(elem)
+
+		return
+	}
+
+	m.items[key] = m.order.PushFront(&memoryItem{key: key, entry: entry})
+
+	if m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// Delete deletes an entry.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		m.order.Remove(elem)
+		delete(m.items, key)
+	}
+}
+
+// Len returns the number of entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// FileCache is a Cache storing the entries as files in a directory.
+// I/O errors are ignored: a failing read is a cache miss.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a new FileCache, creating the directory if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+// Get gets an entry.
+func (f *FileCache) Get(key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(f.filename(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CacheEntry
+
+	err = json.Unmarshal(data, &entry)
+	if err != nil {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Set sets an entry.
+func (f *FileCache) Set(key string, entry *CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	file, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(data)
+	_ = file.Close()
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return
+	}
+
+	if os.Rename(file.Name(), f.filename(key)) != nil {
+		_ = os.Remove(file.Name())
+	}
+}
+
+// Delete deletes an entry.
+func (f *FileCache) Delete(key string) {
+	_ = os.Remove(f.filename(key))
+}
+
+func (f *FileCache) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
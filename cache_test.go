@@ -0,0 +1,180 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingHandler(calls *int32, next http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+		next(rw, req)
+	}
+}
+
+func TestClient_cache(t *testing.T) {
+	client, mux := setupTest(t)
+	client.Cache = NewMemoryCache(10)
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", countingHandler(&calls, testHandler("asn.json")))
+
+	for i := 0; i < 3; i++ {
+		details, err := client.GetASN(context.Background(), 61138)
+		require.NoError(t, err)
+
+		assert.Equal(t, 61138, details.Data.ASN)
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_cache_disabledFamily(t *testing.T) {
+	client, mux := setupTest(t)
+	client.Cache = NewMemoryCache(10)
+	client.CachePolicy = &CachePolicy{DefaultTTL: time.Hour, TTLs: map[string]time.Duration{FamilySearch: 0}}
+
+	var calls int32
+
+	mux.HandleFunc("/search", countingHandler(&calls, testHandler("search.json")))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSearch(context.Background(), "digitalocean")
+		require.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestClient_cache_negative(t *testing.T) {
+	client, mux := setupTest(t)
+	client.Cache = NewMemoryCache(10)
+
+	var calls int32
+
+	mux.HandleFunc("/asn/1", countingHandler(&calls, func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"status":"error","status_message":"Could not find ASN"}`))
+	}))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetASN(context.Background(), 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_cache_staleWhileRevalidate(t *testing.T) {
+	client, mux := setupTest(t)
+	client.Cache = NewMemoryCache(10)
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", countingHandler(&calls, testHandler("asn.json")))
+
+	endpoint := client.baseURL.JoinPath("asn", "61138").String()
+
+	client.Cache.Set(endpoint, &CacheEntry{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"status":"ok","data":{"asn":61138,"name":"STALE"}}`),
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	})
+
+	details, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, "STALE", details.Data.Name)
+
+	assert.Eventually(t, func() bool {
+		entry, ok := client.Cache.Get(endpoint)
+		return ok && entry.ExpiresAt.After(time.Now())
+	}, time.Second, 10*time.Millisecond)
+
+	details, err = client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ZAPPIE-HOST-AS", details.Data.Name)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_cache_expired(t *testing.T) {
+	client, mux := setupTest(t)
+	client.Cache = NewMemoryCache(10)
+	client.CachePolicy = &CachePolicy{DefaultTTL: time.Hour}
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", countingHandler(&calls, testHandler("asn.json")))
+
+	client.Cache.Set(client.baseURL.JoinPath("asn", "61138").String(), &CacheEntry{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"status":"ok","data":{"asn":61138,"name":"STALE"}}`),
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	})
+
+	details, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ZAPPIE-HOST-AS", details.Data.Name)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", &CacheEntry{Body: []byte("a")})
+	cache.Set("b", &CacheEntry{Body: []byte("b")})
+
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", &CacheEntry{Body: []byte("c")})
+
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "b is the least recently used entry")
+
+	entry, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "a", string(entry.Body))
+
+	cache.Delete("a")
+
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestFileCache(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	require.NoError(t, err)
+
+	_, ok := cache.Get("https://api.bgpview.io/asn/61138")
+	require.False(t, ok)
+
+	expected := &CacheEntry{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"status":"ok"}`),
+		ExpiresAt:  time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	cache.Set("https://api.bgpview.io/asn/61138", expected)
+
+	entry, ok := cache.Get("https://api.bgpview.io/asn/61138")
+	require.True(t, ok)
+
+	assert.Equal(t, expected, entry)
+
+	cache.Delete("https://api.bgpview.io/asn/61138")
+
+	_, ok = cache.Get("https://api.bgpview.io/asn/61138")
+	assert.False(t, ok)
+}
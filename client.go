@@ -7,29 +7,87 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"path"
+	"reflect"
 	"strconv"
+	"sync"
 	"time"
 )
 
 const defaultBaseURL = "https://api.bgpview.io"
 
+const statusOK = "ok"
+
 // Client a BGPView API client.
 type Client struct {
 	baseURL *url.URL
+	headers http.Header
+	// timeout is set by WithTimeout, and applied once all the options are applied.
+	timeout *time.Duration
 
 	HTTPClient *http.Client
+
+	// RetryPolicy enables retries of failed requests.
+	// If nil, each request is attempted only once.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter throttles the requests (retries included).
+	// If nil, requests are not throttled.
+	RateLimiter *RateLimiter
+
+	// Cache stores the responses.
+	// If nil, responses are not cached.
+	Cache Cache
+	// CachePolicy defines how responses are cached.
+	// If nil, DefaultCachePolicy is used.
+	CachePolicy *CachePolicy
+
+	// BatchConcurrency is the maximum number of concurrent lookups of the batch methods (GetASNs, GetIPs, GetPrefixes, Enrich).
+	// If <= 0, 4 lookups are run concurrently.
+	BatchConcurrency int
+
+	// Middlewares wrap the HTTP client, the first one being the outermost.
+	Middlewares []Middleware
+
+	// StrictDecoding makes the requests fail with a *SchemaDriftError
+	// when a response contains fields unknown to the response type.
+	StrictDecoding bool
+	// SchemaDriftHandler is called with the unknown and the missing fields of each response, if any.
+	// When it's defined, the requests don't fail on unknown fields, even with StrictDecoding.
+	SchemaDriftHandler func(err *SchemaDriftError)
+
+	flights      *flightGroup
+	revalidating *sync.Map
 }
 
 // NewClient creates a new Client.
-func NewClient() *Client {
+func NewClient(opts ...Option) (*Client, error) {
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	return &Client{
+	client := &Client{
 		baseURL:    baseURL,
 		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+
+		flights:      newFlightGroup(),
+		revalidating: &sync.Map{},
+	}
+
+	for _, opt := range opts {
+		err := opt(client)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	if client.timeout != nil {
+		httpClient := *client.HTTPClient
+		httpClient.Timeout = *client.timeout
+		client.HTTPClient = &httpClient
+	}
+
+	return client, nil
 }
 
 // GetASN gets ASN.
@@ -139,6 +197,26 @@ func (c Client) GetIP(ctx context.Context, ipAddress string) (*IPInfo, error) {
 	return &apiResp, nil
 }
 
+// GetNetPrefix gets Prefix from a netip.Prefix, masked (e.g. 192.0.2.1/24 gets 192.0.2.0/24).
+func (c Client) GetNetPrefix(ctx context.Context, prefix netip.Prefix) (*PrefixInfo, error) {
+	if !prefix.IsValid() {
+		return nil, fmt.Errorf("invalid prefix: %v", prefix)
+	}
+
+	prefix = prefix.Masked()
+
+	return c.GetPrefix(ctx, prefix.Addr().String(), prefix.Bits())
+}
+
+// GetNetIP gets IP from a netip.Addr.
+func (c Client) GetNetIP(ctx context.Context, ipAddress netip.Addr) (*IPInfo, error) {
+	if !ipAddress.IsValid() {
+		return nil, fmt.Errorf("invalid IP address: %v", ipAddress)
+	}
+
+	return c.GetIP(ctx, ipAddress.String())
+}
+
 // GetIX gets IX.
 func (c Client) GetIX(ctx context.Context, ixID int) (*IXInfo, error) {
 	endpoint := c.baseURL.JoinPath("ix", strconv.Itoa(ixID))
@@ -170,24 +248,139 @@ func (c Client) GetSearch(ctx context.Context, term string) (*SearchInfo, error)
 }
 
 func (c Client) do(ctx context.Context, endpoint *url.URL, data interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	body, err := c.cachedFetch(ctx, endpoint)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("accept", "application/json")
+	if c.StrictDecoding || c.SchemaDriftHandler != nil {
+		err = c.checkSchema(endpoint, body, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return err
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	captureRaw(ctx, body)
+
+	return nil
+}
+
+func (c Client) checkSchema(endpoint *url.URL, body []byte, data interface{}) error {
+	name := endpointName(c.baseURL, endpoint)
+
+	unknown, missing, err := detectSchemaDrift(body, reflect.TypeOf(data), name)
 	if err != nil {
 		return err
 	}
 
+	drift := &SchemaDriftError{
+		Endpoint: name,
+		URL:      endpoint.String(),
+		Unknown:  unknown,
+		Missing:  missing,
+	}
+
+	if drift.empty() {
+		return nil
+	}
+
+	if c.SchemaDriftHandler != nil {
+		c.SchemaDriftHandler(drift)
+		return nil
+	}
+
+	if len(drift.Unknown) > 0 {
+		return drift
+	}
+
+	return nil
+}
+
+// sharedFetch fetches an endpoint, sharing the round trip with the concurrent identical requests.
+// Each caller decodes its own copy of the shared body.
+func (c Client) sharedFetch(ctx context.Context, endpoint *url.URL) ([]byte, error) {
+	return c.flights.do(ctx, endpoint.String(), func(ctx context.Context) ([]byte, error) {
+		return c.fetch(ctx, endpoint)
+	})
+}
+
+func (c Client) fetch(ctx context.Context, endpoint *url.URL) ([]byte, error) {
+	family := endpointFamily(c.baseURL, endpoint)
+
+	for attempt := 1; ; attempt++ {
+		err := c.RateLimiter.Wait(ctx, family)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := c.roundTrip(ctx, endpoint)
+		if err == nil {
+			return body, nil
+		}
+
+		if !c.RetryPolicy.shouldRetry(ctx, attempt, err) {
+			return nil, err
+		}
+
+		err = sleep(ctx, c.RetryPolicy.delay(attempt, err))
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c Client) roundTrip(ctx context.Context, endpoint *url.URL) (_ []byte, err error) {
+	ctx, hooks := withRequestHooks(withEndpointName(ctx, endpointName(c.baseURL, endpoint)))
+
+	defer func() { hooks.run(err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+
+	req.Header.Set("accept", "application/json")
+
+	resp, err := c.doer().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
 	defer func() { _ = resp.Body.Close() }()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("%d: %s", resp.StatusCode, string(data))
+		apiErr := newAPIError(endpoint.String(), resp.StatusCode, body)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+		return nil, apiErr
+	}
+
+	var envelope struct {
+		Status string `json:"status"`
+	}
+
+	err = json.Unmarshal(body, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	if envelope.Status != statusOK {
+		return nil, newAPIError(endpoint.String(), resp.StatusCode, body)
 	}
 
-	return json.NewDecoder(resp.Body).Decode(data)
+	return body, nil
 }
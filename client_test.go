@@ -2,15 +2,14 @@ package bgpview
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,9 +22,8 @@ func setupTest(t *testing.T) (*Client, *http.ServeMux) {
 	server := httptest.NewServer(mux)
 	t.Cleanup(server.Close)
 
-	client := NewClient()
-	client.baseURL, _ = url.Parse(server.URL)
-	client.HTTPClient = server.Client()
+	client, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	require.NoError(t, err)
 
 	return client, mux
 }
@@ -37,7 +35,7 @@ func testHandler(filename string) http.HandlerFunc {
 			return
 		}
 
-		file, err := os.Open(filepath.Join("fixtures", filename))
+		file, err := os.Open(filepath.Join("bgpviewtest", "fixtures", filename))
 		if err != nil {
 			http.Error(rw, err.Error(), http.StatusInternalServerError)
 			return
@@ -78,11 +76,17 @@ func TestClient_GetASN(t *testing.T) {
 			TrafficRatio:      "Mostly Outbound",
 			OwnerAddress:      []string{"16192 Coastal HWY", "DE 19958", "Lewes", "UNITED STATES"},
 			RIRAllocation: AllocationData{
-				RIRName:       "RIPE",
-				CountryCode:   "US",
-				DateAllocated: "2015-03-04 00:00:00",
+				RIRName:          "RIPE",
+				CountryCode:      "US",
+				DateAllocated:    Timestamp{Time: time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)},
+				AllocationStatus: "allocated",
+			},
+			IANAAssignment: IANAAssignment{
+				AssignmentStatus: "assigned",
+				Description:      "Assigned by RIPE NCC",
+				WhoisServer:      "whois.ripe.net",
 			},
-			DateUpdated: "2021-11-21 04:02:05",
+			DateUpdated: Timestamp{Time: time.Date(2021, 11, 21, 4, 2, 5, 0, time.UTC)},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "24.48 ms"},
 	}
@@ -103,50 +107,50 @@ func TestClient_GetASNPrefixes(t *testing.T) {
 		StatusMessage: "Query was successful",
 		Data: ASNPrefixesData{
 			IPv4Prefixes: []ASNIPPrefixesData{
-				{Prefix: "45.67.13.0/24", IP: "45.67.13.0", CIDR: 24, RoaStatus: "None", Name: "QUICKVIRT-PRA01", Description: "QUICKVIRT PRA01", CountryCode: "CZ", Parent: ASNPrefixesParent{Prefix: "45.67.12.0/22", IP: "45.67.12.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "45.146.105.0/24", IP: "45.146.105.0", CIDR: 24, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: ""}},
-				{Prefix: "45.155.65.0/24", IP: "45.155.65.0", CIDR: 24, RoaStatus: "None", Name: "Heficed", Description: "UAB Xantho", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "45.155.64.0/22", IP: "45.155.64.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "45.155.66.0/24", IP: "45.155.66.0", CIDR: 24, RoaStatus: "None", Name: "Heficed", Description: "UAB Xantho", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "45.155.64.0/22", IP: "45.155.64.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "89.117.126.0/24", IP: "89.117.126.0", CIDR: 24, RoaStatus: "None", Name: "LT-LRTC-20060503", Description: "SC \"Lithuanian Radio and TV Center\"", CountryCode: "LT", Parent: ASNPrefixesParent{Prefix: "89.116.0.0/15", IP: "89.116.0.0", CIDR: 15, RIRName: "RIPE"}},
-				{Prefix: "103.208.86.0/24", IP: "103.208.86.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-3", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "103.208.84.0/22", IP: "103.208.84.0", CIDR: 22, RIRName: "APNIC"}},
-				{Prefix: "104.247.99.0/24", IP: "104.247.99.0", CIDR: 24, RoaStatus: "None", Name: "DNET", Description: "Dnetworks LLC", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "104.247.99.0/24", IP: "104.247.99.0", CIDR: 24, RIRName: "ARIN"}},
-				{Prefix: "144.48.80.0/24", IP: "144.48.80.0", CIDR: 24, RoaStatus: "None", Name: "BITACCEL-NETWORK", Description: "BitAccel", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "144.48.80.0/22", IP: "144.48.80.0", CIDR: 22, RIRName: "APNIC"}},
-				{Prefix: "169.239.128.0/23", IP: "169.239.128.0", CIDR: 23, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-1", Description: "Zappie Host - Johannesburg, South Africa", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "169.239.128.0/22", IP: "169.239.128.0", CIDR: 22, RIRName: "AfriNIC"}},
-				{Prefix: "169.239.130.0/23", IP: "169.239.130.0", CIDR: 23, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-2", Description: "Zappie Host - Johannesburg, South Africa", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "169.239.128.0/22", IP: "169.239.128.0", CIDR: 22, RIRName: "AfriNIC"}},
-				{Prefix: "185.99.132.0/24", IP: "185.99.132.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.99.132.0/22", IP: "185.99.132.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "185.99.133.0/24", IP: "185.99.133.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.99.132.0/22", IP: "185.99.132.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "185.121.168.0/24", IP: "185.121.168.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.121.168.0/22", IP: "185.121.168.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "185.195.239.0/24", IP: "185.195.239.0", CIDR: 24, RoaStatus: "None", Name: "ZAP-NZ-ISP-PREFIX", Description: "Zappie ISP Services - New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.195.236.0/22", IP: "185.195.236.0", CIDR: 22, RIRName: "RIPE"}},
-				{Prefix: "216.73.158.0/24", IP: "216.73.158.0", CIDR: 24, RoaStatus: "None", Name: "ZAP-CID-6006", Description: "Private Customer", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "216.73.156.0/22", IP: "216.73.156.0", CIDR: 22, RIRName: "ARIN"}},
-				{Prefix: "216.73.159.0/24", IP: "216.73.159.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-CL-1", Description: "Zappie Host - Valdivia, Chile", CountryCode: "CL", Parent: ASNPrefixesParent{Prefix: "216.73.156.0/22", IP: "216.73.156.0", CIDR: 22, RIRName: "ARIN"}},
+				{Prefix: "45.67.13.0/24", IP: "45.67.13.0", CIDR: 24, RoaStatus: "None", Name: "QUICKVIRT-PRA01", Description: "QUICKVIRT PRA01", CountryCode: "CZ", Parent: ASNPrefixesParent{Prefix: "45.67.12.0/22", IP: "45.67.12.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "45.146.105.0/24", IP: "45.146.105.0", CIDR: 24, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: "", AllocationStatus: "unknown"}},
+				{Prefix: "45.155.65.0/24", IP: "45.155.65.0", CIDR: 24, RoaStatus: "None", Name: "Heficed", Description: "UAB Xantho", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "45.155.64.0/22", IP: "45.155.64.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "45.155.66.0/24", IP: "45.155.66.0", CIDR: 24, RoaStatus: "None", Name: "Heficed", Description: "UAB Xantho", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "45.155.64.0/22", IP: "45.155.64.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "89.117.126.0/24", IP: "89.117.126.0", CIDR: 24, RoaStatus: "None", Name: "LT-LRTC-20060503", Description: "SC \"Lithuanian Radio and TV Center\"", CountryCode: "LT", Parent: ASNPrefixesParent{Prefix: "89.116.0.0/15", IP: "89.116.0.0", CIDR: 15, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "103.208.86.0/24", IP: "103.208.86.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-3", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "103.208.84.0/22", IP: "103.208.84.0", CIDR: 22, RIRName: "APNIC", AllocationStatus: "unknown"}},
+				{Prefix: "104.247.99.0/24", IP: "104.247.99.0", CIDR: 24, RoaStatus: "None", Name: "DNET", Description: "Dnetworks LLC", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "104.247.99.0/24", IP: "104.247.99.0", CIDR: 24, RIRName: "ARIN", AllocationStatus: "unknown"}},
+				{Prefix: "144.48.80.0/24", IP: "144.48.80.0", CIDR: 24, RoaStatus: "None", Name: "BITACCEL-NETWORK", Description: "BitAccel", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "144.48.80.0/22", IP: "144.48.80.0", CIDR: 22, RIRName: "APNIC", AllocationStatus: "unknown"}},
+				{Prefix: "169.239.128.0/23", IP: "169.239.128.0", CIDR: 23, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-1", Description: "Zappie Host - Johannesburg, South Africa", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "169.239.128.0/22", IP: "169.239.128.0", CIDR: 22, RIRName: "AfriNIC", AllocationStatus: "unknown"}},
+				{Prefix: "169.239.130.0/23", IP: "169.239.130.0", CIDR: 23, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-2", Description: "Zappie Host - Johannesburg, South Africa", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "169.239.128.0/22", IP: "169.239.128.0", CIDR: 22, RIRName: "AfriNIC", AllocationStatus: "unknown"}},
+				{Prefix: "185.99.132.0/24", IP: "185.99.132.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.99.132.0/22", IP: "185.99.132.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "185.99.133.0/24", IP: "185.99.133.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.99.132.0/22", IP: "185.99.132.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "185.121.168.0/24", IP: "185.121.168.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ", Description: "Zappie Host - Auckland, New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.121.168.0/22", IP: "185.121.168.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "185.195.239.0/24", IP: "185.195.239.0", CIDR: 24, RoaStatus: "None", Name: "ZAP-NZ-ISP-PREFIX", Description: "Zappie ISP Services - New Zealand", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "185.195.236.0/22", IP: "185.195.236.0", CIDR: 22, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "216.73.158.0/24", IP: "216.73.158.0", CIDR: 24, RoaStatus: "None", Name: "ZAP-CID-6006", Description: "Private Customer", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "216.73.156.0/22", IP: "216.73.156.0", CIDR: 22, RIRName: "ARIN", AllocationStatus: "unknown"}},
+				{Prefix: "216.73.159.0/24", IP: "216.73.159.0", CIDR: 24, RoaStatus: "None", Name: "ZAPPIE-HOST-CL-1", Description: "Zappie Host - Valdivia, Chile", CountryCode: "CL", Parent: ASNPrefixesParent{Prefix: "216.73.156.0/22", IP: "216.73.156.0", CIDR: 22, RIRName: "ARIN", AllocationStatus: "unknown"}},
 			},
 			IPv6Prefixes: []ASNIPPrefixesData{
-				{Prefix: "2404:3d80::/32", IP: "2404:3d80::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIEHOST-AP-20160203", Description: "Zappie Host LLC", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2404:3d80::/32", IP: "2404:3d80::", CIDR: 32, RIRName: "APNIC"}},
-				{Prefix: "2407:c280:b103::/48", IP: "2407:c280:b103::", CIDR: 48, RoaStatus: "None", Name: "DavidLiu", Description: "fixmix Technologies Ltd", CountryCode: "EU", Parent: ASNPrefixesParent{Prefix: "2407:c280::/32", IP: "2407:c280::", CIDR: 32, RIRName: "APNIC"}},
-				{Prefix: "2a05:dfc0::/29", IP: "2a05:dfc0::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20150303", Description: "Zappie Host LLC", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "2a05:dfc0::/29", IP: "2a05:dfc0::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20150507", Description: "Zappie Host LLC", CountryCode: "BY", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280::/32", IP: "2a06:1280::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:ae02::/48", IP: "2a06:1280:ae02::", CIDR: 48, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:aee1::/48", IP: "2a06:1280:aee1::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:c0de::/48", IP: "2a06:1280:c0de::", CIDR: 48, RoaStatus: "None", Name: "THENETWORKCREW-AU", Description: "The Network Crew Pty Ltd", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:ce01::/48", IP: "2a06:1280:ce01::", CIDR: 48, RoaStatus: "None", Name: "NICE-CO-NZ-v6", Description: "Nice.co.nz Ltd IPv6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:ce02::/48", IP: "2a06:1280:ce02::", CIDR: 48, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:1280:ce04::/48", IP: "2a06:1280:ce04::", CIDR: 48, RoaStatus: "None", Name: "c9bdd059-ad72-4382-8d8d-da98a40d563e", Description: "Kevin Holly trading as Silent Ghost e.U.", CountryCode: "NL", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:9f80::/29", IP: "2a06:9f80::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20151015", Description: "Zappie Host LLC", CountryCode: "VA", Parent: ASNPrefixesParent{Prefix: "2a06:9f80::/29", IP: "2a06:9f80::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:a006::/32", IP: "2a06:a006::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIE-HOST-CL-1", Description: "Zappie Host - Valdivia, Chile", CountryCode: "CL", Parent: ASNPrefixesParent{Prefix: "2a06:a000::/29", IP: "2a06:a000::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a06:e881:6203::/48", IP: "2a06:e881:6203::", CIDR: 48, RoaStatus: "None", Name: "FR-RANXPLORER-20190213", Description: "Ranxplorer", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a06:e880::/29", IP: "2a06:e880::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a07:54c0::/29", IP: "2a07:54c0::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20160412", Description: "Zappie Host LLC", CountryCode: "IM", Parent: ASNPrefixesParent{Prefix: "2a07:54c0::/29", IP: "2a07:54c0::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a09:54c0::/29", IP: "2a09:54c0::", CIDR: 29, RoaStatus: "None", Name: "NZ-KIWIANAHOSTING-20190208", Description: "Kiwiana Hosting Limited", CountryCode: "CY", Parent: ASNPrefixesParent{Prefix: "2a09:54c0::/29", IP: "2a09:54c0::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a0a:6040::/29", IP: "2a0a:6040::", CIDR: 29, RoaStatus: "None", Name: "US-ZAP-20170323", Description: "Zappie Host LLC", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: ""}},
-				{Prefix: "2a0b:9e40::/29", IP: "2a0b:9e40::", CIDR: 29, RoaStatus: "None", Name: "NZ-MONTEHOSTING-20170726", Description: "MonteHosting LTD", CountryCode: "ME", Parent: ASNPrefixesParent{Prefix: "2a0b:9e40::/29", IP: "2a0b:9e40::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a0c:9a40:8082::/48", IP: "2a0c:9a40:8082::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: ""}},
-				{Prefix: "2a0c:b642:1a0e::/48", IP: "2a0c:b642:1a0e::", CIDR: 48, RoaStatus: "None", Name: "RANEXPLORER_220219", Description: "Ranxplorer", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a0c:b640::/29", IP: "2a0c:b640::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a0c:e640:24::/48", IP: "2a0c:e640:24::", CIDR: 48, RoaStatus: "None", Name: "FIXMIX-NET6-AKL", Description: "fixmix GEN - Auckland", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a0c:e640::/29", IP: "2a0c:e640::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a0d:d900::/29", IP: "2a0d:d900::", CIDR: 29, RoaStatus: "None", Name: "NZ-HOSTINGLOVE-20171213", Description: "HostingLove LImited", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a0d:d900::/29", IP: "2a0d:d900::", CIDR: 29, RIRName: "RIPE"}},
-				{Prefix: "2a0e:fd45:40fd::/48", IP: "2a0e:fd45:40fd::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: ""}},
-				{Prefix: "2a0f:5707:ab2f::/48", IP: "2a0f:5707:ab2f::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: ""}},
-				{Prefix: "2c0f:f530::/44", IP: "2c0f:f530::", CIDR: 44, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-V6", Description: "Zappie Host - Johannesburg, South Africa v6", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "2c0f:f530::/32", IP: "2c0f:f530::", CIDR: 32, RIRName: "AfriNIC"}},
-				{Prefix: "2c0f:f530:20::/44", IP: "2c0f:f530:20::", CIDR: 44, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-V6", Description: "Zappie Host - Johannesburg, South Africa v6", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "2c0f:f530::/32", IP: "2c0f:f530::", CIDR: 32, RIRName: "AfriNIC"}},
+				{Prefix: "2404:3d80::/32", IP: "2404:3d80::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIEHOST-AP-20160203", Description: "Zappie Host LLC", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2404:3d80::/32", IP: "2404:3d80::", CIDR: 32, RIRName: "APNIC", AllocationStatus: "unknown"}},
+				{Prefix: "2407:c280:b103::/48", IP: "2407:c280:b103::", CIDR: 48, RoaStatus: "None", Name: "DavidLiu", Description: "fixmix Technologies Ltd", CountryCode: "EU", Parent: ASNPrefixesParent{Prefix: "2407:c280::/32", IP: "2407:c280::", CIDR: 32, RIRName: "APNIC", AllocationStatus: "unknown"}},
+				{Prefix: "2a05:dfc0::/29", IP: "2a05:dfc0::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20150303", Description: "Zappie Host LLC", CountryCode: "GB", Parent: ASNPrefixesParent{Prefix: "2a05:dfc0::/29", IP: "2a05:dfc0::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20150507", Description: "Zappie Host LLC", CountryCode: "BY", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280::/32", IP: "2a06:1280::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:ae02::/48", IP: "2a06:1280:ae02::", CIDR: 48, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:aee1::/48", IP: "2a06:1280:aee1::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:c0de::/48", IP: "2a06:1280:c0de::", CIDR: 48, RoaStatus: "None", Name: "THENETWORKCREW-AU", Description: "The Network Crew Pty Ltd", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:ce01::/48", IP: "2a06:1280:ce01::", CIDR: 48, RoaStatus: "None", Name: "NICE-CO-NZ-v6", Description: "Nice.co.nz Ltd IPv6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:ce02::/48", IP: "2a06:1280:ce02::", CIDR: 48, RoaStatus: "None", Name: "ZAPPIE-HOST-NZ-v6", Description: "Zappie Host - Auckland, New Zealand v6", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:1280:ce04::/48", IP: "2a06:1280:ce04::", CIDR: 48, RoaStatus: "None", Name: "c9bdd059-ad72-4382-8d8d-da98a40d563e", Description: "Kevin Holly trading as Silent Ghost e.U.", CountryCode: "NL", Parent: ASNPrefixesParent{Prefix: "2a06:1280::/29", IP: "2a06:1280::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:9f80::/29", IP: "2a06:9f80::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20151015", Description: "Zappie Host LLC", CountryCode: "VA", Parent: ASNPrefixesParent{Prefix: "2a06:9f80::/29", IP: "2a06:9f80::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:a006::/32", IP: "2a06:a006::", CIDR: 32, RoaStatus: "None", Name: "ZAPPIE-HOST-CL-1", Description: "Zappie Host - Valdivia, Chile", CountryCode: "CL", Parent: ASNPrefixesParent{Prefix: "2a06:a000::/29", IP: "2a06:a000::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a06:e881:6203::/48", IP: "2a06:e881:6203::", CIDR: 48, RoaStatus: "None", Name: "FR-RANXPLORER-20190213", Description: "Ranxplorer", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a06:e880::/29", IP: "2a06:e880::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a07:54c0::/29", IP: "2a07:54c0::", CIDR: 29, RoaStatus: "None", Name: "US-ZAPPIE-20160412", Description: "Zappie Host LLC", CountryCode: "IM", Parent: ASNPrefixesParent{Prefix: "2a07:54c0::/29", IP: "2a07:54c0::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a09:54c0::/29", IP: "2a09:54c0::", CIDR: 29, RoaStatus: "None", Name: "NZ-KIWIANAHOSTING-20190208", Description: "Kiwiana Hosting Limited", CountryCode: "CY", Parent: ASNPrefixesParent{Prefix: "2a09:54c0::/29", IP: "2a09:54c0::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a0a:6040::/29", IP: "2a0a:6040::", CIDR: 29, RoaStatus: "None", Name: "US-ZAP-20170323", Description: "Zappie Host LLC", CountryCode: "US", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: "", AllocationStatus: "unknown"}},
+				{Prefix: "2a0b:9e40::/29", IP: "2a0b:9e40::", CIDR: 29, RoaStatus: "None", Name: "NZ-MONTEHOSTING-20170726", Description: "MonteHosting LTD", CountryCode: "ME", Parent: ASNPrefixesParent{Prefix: "2a0b:9e40::/29", IP: "2a0b:9e40::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a0c:9a40:8082::/48", IP: "2a0c:9a40:8082::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: "", AllocationStatus: "unknown"}},
+				{Prefix: "2a0c:b642:1a0e::/48", IP: "2a0c:b642:1a0e::", CIDR: 48, RoaStatus: "None", Name: "RANEXPLORER_220219", Description: "Ranxplorer", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a0c:b640::/29", IP: "2a0c:b640::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a0c:e640:24::/48", IP: "2a0c:e640:24::", CIDR: 48, RoaStatus: "None", Name: "FIXMIX-NET6-AKL", Description: "fixmix GEN - Auckland", CountryCode: "NZ", Parent: ASNPrefixesParent{Prefix: "2a0c:e640::/29", IP: "2a0c:e640::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a0d:d900::/29", IP: "2a0d:d900::", CIDR: 29, RoaStatus: "None", Name: "NZ-HOSTINGLOVE-20171213", Description: "HostingLove LImited", CountryCode: "FR", Parent: ASNPrefixesParent{Prefix: "2a0d:d900::/29", IP: "2a0d:d900::", CIDR: 29, RIRName: "RIPE", AllocationStatus: "unknown"}},
+				{Prefix: "2a0e:fd45:40fd::/48", IP: "2a0e:fd45:40fd::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: "", AllocationStatus: "unknown"}},
+				{Prefix: "2a0f:5707:ab2f::/48", IP: "2a0f:5707:ab2f::", CIDR: 48, RoaStatus: "None", Name: "", Description: "", CountryCode: "", Parent: ASNPrefixesParent{Prefix: "", IP: "", CIDR: 0, RIRName: "", AllocationStatus: "unknown"}},
+				{Prefix: "2c0f:f530::/44", IP: "2c0f:f530::", CIDR: 44, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-V6", Description: "Zappie Host - Johannesburg, South Africa v6", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "2c0f:f530::/32", IP: "2c0f:f530::", CIDR: 32, RIRName: "AfriNIC", AllocationStatus: "unknown"}},
+				{Prefix: "2c0f:f530:20::/44", IP: "2c0f:f530:20::", CIDR: 44, RoaStatus: "None", Name: "ZAPPIE-HOST-ZA-V6", Description: "Zappie Host - Johannesburg, South Africa v6", CountryCode: "ZA", Parent: ASNPrefixesParent{Prefix: "2c0f:f530::/32", IP: "2c0f:f530::", CIDR: 32, RIRName: "AfriNIC", AllocationStatus: "unknown"}},
 			},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "50.85 ms"},
@@ -226,6 +230,9 @@ func TestClient_GetASNUpstreams(t *testing.T) {
 				{ASN: 35661, Name: "VIRTUA-SYSTEMS", Description: "VIRTUA SYSTEMS SAS", CountryCode: "FR"},
 				{ASN: 36369, Name: "LIMEWAVE", Description: "Limewave Communications", CountryCode: "CA"},
 			},
+			IPv4Graph:     "https://api.bgpview.io/assets/graphs/AS61138_IPv4.svg",
+			IPv6Graph:     "https://api.bgpview.io/assets/graphs/AS61138_IPv6.svg",
+			CombinedGraph: "https://api.bgpview.io/assets/graphs/AS61138_Combined.svg",
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "24.1 ms"},
 	}
@@ -274,11 +281,11 @@ func TestClient_GetASNIxs(t *testing.T) {
 		Status:        "ok",
 		StatusMessage: "Query was successful",
 		Data: []ASNIxsData{
-			{IxID: 585, Name: "EVIX", NameFull: "Experimental Virtual Internet Exchange", CountryCode: "US", IPv4Address: "206.81.104.165", IPv6Address: "2602:fed2:fff:ffff::165", Speed: 100},
-			{IxID: 599, Name: "LL-IX", NameFull: "LL-IX", CountryCode: "RO", IPv4Address: "5.101.92.255", IPv6Address: "2001:678:4fc::92:255", Speed: 100},
-			{IxID: 780, Name: "TOHU IX", NameFull: "TOHU IX", CountryCode: "CN", IPv4Address: "", IPv6Address: "2406:840:eb8f:1:0:6:1138:1", Speed: 100},
-			{IxID: 829, Name: "PyramIX", NameFull: "Pyramids Internet Exchange", CountryCode: "EG", IPv4Address: "104.167.214.111", IPv6Address: "2a0e:46c4:102::611:38:1", Speed: 100},
-			{IxID: 857, Name: "HamroIX-Amsterdam", NameFull: "Hamro Internet eXchange", CountryCode: "NL", IPv4Address: "104.167.214.131", IPv6Address: "2a0e:b107:f21::113", Speed: 100},
+			{IxID: 585, Name: "EVIX", NameFull: "Experimental Virtual Internet Exchange", CountryCode: "US", City: "Fremont", IPv4Address: "206.81.104.165", IPv6Address: "2602:fed2:fff:ffff::165", Speed: 100},
+			{IxID: 599, Name: "LL-IX", NameFull: "LL-IX", CountryCode: "RO", City: "Haarlem, Katowice, Fremont, Warsaw", IPv4Address: "5.101.92.255", IPv6Address: "2001:678:4fc::92:255", Speed: 100},
+			{IxID: 780, Name: "TOHU IX", NameFull: "TOHU IX", CountryCode: "CN", City: "Guangzhou", IPv4Address: "", IPv6Address: "2406:840:eb8f:1:0:6:1138:1", Speed: 100},
+			{IxID: 829, Name: "PyramIX", NameFull: "Pyramids Internet Exchange", CountryCode: "EG", City: "Cairo", IPv4Address: "104.167.214.111", IPv6Address: "2a0e:46c4:102::611:38:1", Speed: 100},
+			{IxID: 857, Name: "HamroIX-Amsterdam", NameFull: "Hamro Internet eXchange", CountryCode: "NL", City: "Amsterdam", IPv4Address: "104.167.214.131", IPv6Address: "2a0e:b107:f21::113", Speed: 100},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "22.68 ms"},
 	}
@@ -306,6 +313,19 @@ func TestClient_GetPrefix(t *testing.T) {
 				Name:        "SPRINTLINK",
 				Description: "Sprint",
 				CountryCode: "US",
+				PrefixUpstreams: []ASN{
+					{ASN: 3320, Name: "DTAG", Description: "Internet service provider operations", CountryCode: "DE"},
+					{ASN: 2497, Name: "IIJ", Description: "Internet Initiative Japan Inc.", CountryCode: "JP"},
+					{ASN: 3356, Name: "LEVEL3", Description: "Level 3 Parent, LLC", CountryCode: "US"},
+					{ASN: 2914, Name: "NTT-COMMUNICATIONS-2914", Description: "NTT America, Inc.", CountryCode: "US"},
+					{ASN: 701, Name: "UUNET", Description: "MCI Communications Services, Inc. d/b/a Verizon Business", CountryCode: "US"},
+					{ASN: 6453, Name: "AS6453", Description: "TATA COMMUNICATIONS (AMERICA) INC", CountryCode: "US"},
+					{ASN: 174, Name: "COGENT-174", Description: "Cogent Communications", CountryCode: "US"},
+					{ASN: 1299, Name: "TWELVE99", Description: "Twelve99, Telia Carrier", CountryCode: "SE"},
+					{ASN: 3257, Name: "GTT-BACKBONE", Description: "GTT", CountryCode: "US"},
+					{ASN: 7018, Name: "ATT-INTERNET4", Description: "AT&T Services, Inc.", CountryCode: "US"},
+					{ASN: 6461, Name: "ZAYO-6461", Description: "Zayo Bandwidth", CountryCode: "US"},
+				},
 			}},
 			Name:             "BITACCEL-NETWORK",
 			DescriptionShort: "BitAccel",
@@ -314,9 +334,11 @@ func TestClient_GetPrefix(t *testing.T) {
 			AbuseContacts:    []string{"abuse@bitaccel.com"},
 			OwnerAddress:     []string{"135 Red Head Ln.", "Gilmer", "TX", "75645", "US"},
 			CountryCodes:     CountryCodeData{WhoisCountryCode: "US", RIRAllocationCountryCode: "US", MaxmindCountryCode: ""},
-			RIRAllocation:    AllocationData{RIRName: "ARIN", CountryCode: "US", IP: "192.209.62.0", CIDR: 23, Prefix: "192.209.62.0/23", DateAllocated: "2015-04-28 00:00:00"},
+			RIRAllocation:    AllocationData{RIRName: "ARIN", CountryCode: "US", IP: "192.209.62.0", CIDR: 23, Prefix: "192.209.62.0/23", DateAllocated: Timestamp{Time: time.Date(2015, 4, 28, 0, 0, 0, 0, time.UTC)}, AllocationStatus: "allocated"},
+			IANAAssignment:   IANAAssignment{AssignmentStatus: "assigned", Description: "Assigned by ARIN", WhoisServer: "whois.arin.net"},
 			MaxMind:          MaxMindData{},
-			DateUpdated:      "2020-12-06 03:30:13",
+			RelatedPrefixes:  []PrefixData{},
+			DateUpdated:      Timestamp{Time: time.Date(2020, 12, 6, 3, 30, 13, 0, time.UTC)},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "442.72 ms"},
 	}
@@ -336,15 +358,20 @@ func TestClient_GetIP(t *testing.T) {
 		Status:        "ok",
 		StatusMessage: "Query was successful",
 		Data: IPData{
+			IP: "2a05:dfc7:60::",
 			Prefixes: []PrefixData{
 				{
-					Prefix: "2a05:dfc0::/29",
-					IP:     "2a05:dfc0::",
-					CIDR:   29,
-					Name:   "US-ZAPPIE-20150303",
+					Prefix:      "2a05:dfc0::/29",
+					IP:          "2a05:dfc0::",
+					CIDR:        29,
+					ASN:         ASN{ASN: 61138, Name: "ZAPPIE-HOST-AS", Description: "Zappie Host", CountryCode: "US"},
+					Name:        "US-ZAPPIE-20150303",
+					Description: "Zappie Host LLC",
+					CountryCode: "GB",
 				},
 			},
-			RIRAllocation: IPAllocationData{RIRName: "RIPE", CountryCode: "US", IP: "2a05:dfc0::", CIDR: "29", Prefix: "2a05:dfc0::/29", DateAllocated: "2015-03-03 00:00:00"},
+			RIRAllocation:  AllocationData{RIRName: "RIPE", CountryCode: "US", IP: "2a05:dfc0::", CIDR: 29, Prefix: "2a05:dfc0::/29", DateAllocated: Timestamp{Time: time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)}, AllocationStatus: "allocated"},
+			IANAAssignment: IANAAssignment{AssignmentStatus: "allocated", Description: "RIPE NCC", WhoisServer: "whois.ripe.net"},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "57.3 ms"},
 	}
@@ -371,7 +398,6 @@ func TestClient_GetIX(t *testing.T) {
 			PolicyEmail:  "mixp@ac.me",
 			City:         "Podgorica",
 			CountryCode:  "ME",
-			URLStats:     json.RawMessage{0x6e, 0x75, 0x6c, 0x6c},
 			MembersCount: 2,
 			Members: []MemberData{
 				{ASN: 200608, Name: "MIXP", Description: "University of Montenegro", CountryCode: "ME", IPv4Address: "185.1.44.1", IPv6Address: "2001:7f8:22::1", Speed: 1000},
@@ -424,6 +450,7 @@ func TestClient_GetSearch(t *testing.T) {
 				{Prefix: "2a03:b0c0::/48", IP: "2a03:b0c0::", CIDR: 48, Name: "DIGITALOCEAN", CountryCode: "NL", Description: "DIGITALOCEAN", EmailContacts: []string{"abuse@digitalocean.com", "noc@digitalocean.com"}, AbuseContacts: []string{"abuse@digitalocean.com"}, RIRName: "RIPE", ParentPrefix: "2a03:b0c0::/32", ParentIP: "2a03:b0c0::", ParentCIDR: 32},
 				{Prefix: "2a03:b0c0::/32", IP: "2a03:b0c0::", CIDR: 32, Name: "US-DIGITALOCEANLLC-20121228", CountryCode: "NL", Description: "DigitalOcean, LLC", EmailContacts: []string{"abuse@digitalocean.com", "noc@digitalocean.com"}, AbuseContacts: []string{"abuse@digitalocean.com"}, RIRName: "RIPE", ParentPrefix: "2a03:b0c0::/32", ParentIP: "2a03:b0c0::", ParentCIDR: 32},
 			},
+			IXs: []SearchIXData{},
 		},
 		Meta: Meta{TimeZone: "UTC", APIVersion: 1, ExecutionTime: "95.27 ms"},
 	}
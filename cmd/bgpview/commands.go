@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/electrologue/bgpview"
+	"github.com/electrologue/bgpview/render"
+)
+
+type command struct {
+	name    string
+	arg     string
+	summary string
+	run     func(ctx context.Context, client bgpview.API, arg string) (interface{}, error)
+	// stream writes its output as it goes, instead of returning a response to render.
+	stream func(ctx context.Context, client bgpview.API, arg string, sio streamIO) error
+	// sessionCache caches the responses in memory while the command runs.
+	sessionCache bool
+}
+
+// streamIO is the input and the output of a streaming command.
+type streamIO struct {
+	stdin  io.Reader
+	stdout io.Writer
+	format render.Format
+}
+
+var commands = []command{
+	{name: "asn", arg: "<asn>", summary: "details of an ASN", run: asnCommand(bgpview.API.GetASN)},
+	{name: "prefixes", arg: "<asn>", summary: "prefixes announced by an ASN", run: asnCommand(bgpview.API.GetASNPrefixes)},
+	{name: "peers", arg: "<asn>", summary: "peers of an ASN", run: asnCommand(bgpview.API.GetASNPeers)},
+	{name: "upstreams", arg: "<asn>", summary: "upstreams of an ASN", run: asnCommand(bgpview.API.GetASNUpstreams)},
+	{name: "downstreams", arg: "<asn>", summary: "downstreams of an ASN", run: asnCommand(bgpview.API.GetASNDownstreams)},
+	{name: "ixs", arg: "<asn>", summary: "IXs of an ASN", run: asnCommand(bgpview.API.GetASNIxs)},
+	{name: "prefix", arg: "<prefix>", summary: "details of a prefix (e.g. 192.0.2.0/24)", run: prefixCommand},
+	{name: "ip", arg: "<ip>", summary: "details of an IP address", run: ipCommand},
+	{name: "ix", arg: "<ix-id>", summary: "details of an IX", run: ixCommand},
+	{name: "search", arg: "<term>", summary: "search ASNs, prefixes and IXs", run: searchCommand},
+	{name: "lookup", arg: "<query>", summary: "ASN, IP, prefix, IX (e.g. IX492) or search, guessed from the query", run: lookupCommand},
+	{name: "enrich", arg: "<file|->", summary: "enrich IPs and ASNs, one by line (CSV or NDJSON)", stream: enrichCommand},
+	{name: "explore", arg: "<asn>", summary: "explore an ASN interactively", stream: exploreCommand, sessionCache: true},
+}
+
+// usageError is an invalid command argument.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+func asnCommand[T any](get func(api bgpview.API, ctx context.Context, asNumber int) (T, error)) func(context.Context, bgpview.API, string) (interface{}, error) {
+	return func(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+		asNumber, err := parseASN(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return bgpview.Raw(ctx, func(ctx context.Context, asNumber int) (T, error) {
+			return get(client, ctx, asNumber)
+		}, asNumber)
+	}
+}
+
+func prefixCommand(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(arg))
+	if err != nil {
+		return nil, usageError{err: fmt.Errorf("invalid prefix %q", arg)}
+	}
+
+	return bgpview.Raw(ctx, client.GetNetPrefix, prefix)
+}
+
+func ipCommand(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+	ip, err := netip.ParseAddr(strings.TrimSpace(arg))
+	if err != nil {
+		return nil, usageError{err: fmt.Errorf("invalid IP address %q", arg)}
+	}
+
+	return bgpview.Raw(ctx, client.GetNetIP, ip)
+}
+
+func ixCommand(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+	ixID, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || ixID <= 0 {
+		return nil, usageError{err: fmt.Errorf("invalid IX ID %q", arg)}
+	}
+
+	return bgpview.Raw(ctx, client.GetIX, ixID)
+}
+
+func searchCommand(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+	term := strings.TrimSpace(arg)
+	if term == "" {
+		return nil, usageError{err: errors.New("empty search term")}
+	}
+
+	return bgpview.Raw(ctx, client.GetSearch, term)
+}
+
+func lookupCommand(ctx context.Context, client bgpview.API, arg string) (interface{}, error) {
+	if strings.TrimSpace(arg) == "" {
+		return nil, usageError{err: errors.New("empty query")}
+	}
+
+	result, err := bgpview.Raw(ctx, client.Lookup, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bgpview.RawResponse[interface{}]{Response: result.Response.Response(), Raw: result.Raw}, nil
+}
+
+// enrichCommand enriches the IP addresses and the ASNs of a file, or of the standard input if the file is "-".
+// The JSON format is written as NDJSON.
+func enrichCommand(ctx context.Context, client bgpview.API, arg string, sio streamIO) error {
+	format := sio.format
+	if format == render.FormatJSON {
+		format = render.FormatNDJSON
+	}
+
+	stream, err := render.NewStream(sio.stdout, format)
+	if err != nil {
+		return usageError{err: err}
+	}
+
+	input := sio.stdin
+
+	if arg != "-" {
+		file, err := os.Open(arg)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = file.Close() }()
+
+		input = file
+	}
+
+	return client.Enrich(ctx, input, func(e *bgpview.Enrichment) error {
+		return stream.Write(e)
+	})
+}
+
+// parseASN parses an ASN (e.g. "61138", "AS61138", "as61138").
+func parseASN(value string) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	if len(trimmed) > 2 && strings.EqualFold(trimmed[:2], "AS") {
+		trimmed = trimmed[2:]
+	}
+
+	asNumber, err := strconv.ParseUint(trimmed, 10, 32)
+	if err != nil || asNumber == 0 {
+		return 0, usageError{err: fmt.Errorf("invalid ASN %q", value)}
+	}
+
+	return int(asNumber), nil
+}
+
+// exitCode returns the exit code of an error.
+func exitCode(err error) int {
+	var usageErr usageError
+	var urlErr *url.Error
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, bgpview.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, bgpview.ErrRateLimited):
+		return exitRateLimited
+	case errors.As(err, &urlErr), errors.As(err, &netErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return exitNetwork
+	default:
+		return exitError
+	}
+}
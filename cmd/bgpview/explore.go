@@ -0,0 +1,500 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/electrologue/bgpview"
+)
+
+const (
+	// explorePageSize is the number of items by page of the explorer.
+	explorePageSize = 20
+	// sessionCacheSize is the number of responses cached by the interactive commands.
+	sessionCacheSize = 1000
+)
+
+const exploreHelp = `Commands:
+  <number>         open an item
+  n, next          next page
+  p, prev          previous page
+  b, back          go back
+  asn <asn>        open an ASN
+  prefix <prefix>  open a prefix
+  ix <ix-id>       open an IX
+  h, help          this help
+  q, quit          quit
+`
+
+// exploreView is a screen of the explorer: the details of a resource, and the items that can be opened.
+type exploreView struct {
+	title   string
+	details [][2]string
+	items   []exploreItem
+	page    int
+}
+
+// exploreItem is an item of a view, its label columns being separated by tabs.
+type exploreItem struct {
+	label string
+	open  func(ctx context.Context) (*exploreView, error)
+}
+
+// explorer browses the ASNs, their prefixes, peers, upstreams, downstreams and IXs.
+type explorer struct {
+	client bgpview.API
+	out    io.Writer
+	stack  []*exploreView
+}
+
+// exploreCommand explores an ASN interactively, the commands being read line by line.
+func exploreCommand(ctx context.Context, client bgpview.API, arg string, sio streamIO) error {
+	asNumber, err := parseASN(arg)
+	if err != nil {
+		return err
+	}
+
+	e := &explorer{client: client, out: sio.stdout}
+
+	view, err := e.asnView(ctx, asNumber)
+	if err != nil {
+		return err
+	}
+
+	e.push(view)
+
+	return e.run(ctx, sio.stdin)
+}
+
+func (e *explorer) run(ctx context.Context, in io.Reader) error {
+	lines := scanLines(in)
+
+	for {
+		fmt.Fprintf(e.out, "%s> ", e.path())
+
+		var line scannedLine
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(e.out)
+			return ctx.Err()
+
+		case line = <-lines:
+		}
+
+		if line.end {
+			fmt.Fprintln(e.out)
+			return line.err
+		}
+
+		if !e.handle(ctx, strings.Fields(line.text)) {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// scannedLine is a line of the input, or its end.
+type scannedLine struct {
+	text string
+	end  bool
+	err  error
+}
+
+// scanLines reads the lines of the input in a goroutine,
+// so that waiting for a line can be interrupted by the context.
+// The goroutine stays blocked on the input once the context is done, until the process exits.
+func scanLines(in io.Reader) <-chan scannedLine {
+	lines := make(chan scannedLine)
+
+	go func() {
+		scanner := bufio.NewScanner(in)
+
+		for scanner.Scan() {
+			lines <- scannedLine{text: scanner.Text()}
+		}
+
+		lines <- scannedLine{end: true, err: scanner.Err()}
+	}()
+
+	return lines
+}
+
+// handle runs a command, and reports whether to continue.
+func (e *explorer) handle(ctx context.Context, fields []string) bool {
+	current := e.stack[len(e.stack)-1]
+
+	if len(fields) == 0 {
+		e.show()
+		return true
+	}
+
+	name := strings.ToLower(fields[0])
+	arg := strings.Join(fields[1:], " ")
+
+	switch name {
+	case "q", "quit", "exit":
+		return false
+
+	case "h", "help", "?":
+		fmt.Fprint(e.out, exploreHelp)
+
+	case "b", "back":
+		if len(e.stack) == 1 {
+			fmt.Fprintln(e.out, "already at the first view")
+			return true
+		}
+
+		e.stack = e.stack[:len(e.stack)-1]
+		e.show()
+
+	case "n", "next", "p", "prev":
+		page := current.page + 1
+		if name == "p" || name == "prev" {
+			page = current.page - 1
+		}
+
+		if page < 0 || page >= pageCount(current) {
+			fmt.Fprintln(e.out, "no such page")
+			return true
+		}
+
+		current.page = page
+		e.show()
+
+	case "asn":
+		e.open(ctx, func(ctx context.Context) (*exploreView, error) {
+			asNumber, err := parseASN(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			return e.asnView(ctx, asNumber)
+		})
+
+	case "prefix":
+		e.open(ctx, func(ctx context.Context) (*exploreView, error) {
+			ip, cidrText, ok := strings.Cut(arg, "/")
+
+			cidr, err := strconv.Atoi(cidrText)
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid prefix %q", arg)
+			}
+
+			return e.prefixView(ctx, ip, cidr)
+		})
+
+	case "ix":
+		e.open(ctx, func(ctx context.Context) (*exploreView, error) {
+			ixID, err := strconv.Atoi(arg)
+			if err != nil || ixID <= 0 {
+				return nil, fmt.Errorf("invalid IX ID %q", arg)
+			}
+
+			return e.ixView(ctx, ixID)
+		})
+
+	default:
+		index, err := strconv.Atoi(name)
+		if err != nil {
+			fmt.Fprintf(e.out, "unknown command %q (h for help)\n", fields[0])
+			return true
+		}
+
+		if index < 1 || index > len(current.items) {
+			fmt.Fprintf(e.out, "no item %d\n", index)
+			return true
+		}
+
+		e.open(ctx, current.items[index-1].open)
+	}
+
+	return true
+}
+
+// open opens a view, or prints the error and stays on the current view.
+func (e *explorer) open(ctx context.Context, open func(ctx context.Context) (*exploreView, error)) {
+	view, err := open(ctx)
+	if err != nil {
+		fmt.Fprintf(e.out, "error: %v\n", err)
+		return
+	}
+
+	e.push(view)
+}
+
+func (e *explorer) push(view *exploreView) {
+	e.stack = append(e.stack, view)
+	e.show()
+}
+
+// path returns the titles of the views, from the first one.
+func (e *explorer) path() string {
+	titles := make([]string, len(e.stack))
+	for i, view := range e.stack {
+		titles[i] = view.title
+	}
+
+	return strings.Join(titles, " / ")
+}
+
+// show prints the current view: its details, and the items of its current page.
+func (e *explorer) show() {
+	view := e.stack[len(e.stack)-1]
+
+	fmt.Fprintf(e.out, "\n%s\n\n", e.path())
+
+	tw := tabwriter.NewWriter(e.out, 0, 0, 2, ' ', 0)
+
+	for _, detail := range view.details {
+		if detail[1] != "" {
+			fmt.Fprintf(tw, "  %s\t%s\n", detail[0], detail[1])
+		}
+	}
+
+	_ = tw.Flush()
+
+	if len(view.details) > 0 && len(view.items) > 0 {
+		fmt.Fprintln(e.out)
+	}
+
+	start := view.page * explorePageSize
+	end := start + explorePageSize
+	if end > len(view.items) {
+		end = len(view.items)
+	}
+
+	for i := start; i < end; i++ {
+		fmt.Fprintf(tw, "  %d\t%s\n", i+1, view.items[i].label)
+	}
+
+	_ = tw.Flush()
+
+	if len(view.items) == 0 && len(view.details) == 0 {
+		fmt.Fprintln(e.out, "  (empty)")
+	}
+
+	if pages := pageCount(view); pages > 1 {
+		fmt.Fprintf(e.out, "\n  page %d/%d (n: next, p: previous)\n", view.page+1, pages)
+	}
+
+	fmt.Fprintln(e.out)
+}
+
+func pageCount(view *exploreView) int {
+	return (len(view.items) + explorePageSize - 1) / explorePageSize
+}
+
+func (e *explorer) asnView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASN(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	data := info.Data
+
+	traffic := data.TrafficEstimation
+	if data.TrafficRatio != "" {
+		traffic = strings.TrimSpace(traffic + " (" + string(data.TrafficRatio) + ")")
+	}
+
+	return &exploreView{
+		title: fmt.Sprintf("AS%d", asNumber),
+		details: [][2]string{
+			{"name", data.Name},
+			{"description", data.DescriptionShort},
+			{"country", data.CountryCode},
+			{"website", data.Website},
+			{"looking glass", data.LookingGlass},
+			{"traffic", traffic},
+			{"abuse", strings.Join(data.AbuseContacts, ", ")},
+			{"RIR", string(data.RIRAllocation.RIRName)},
+			{"updated", data.DateUpdated.String()},
+		},
+		items: []exploreItem{
+			{label: "prefixes", open: func(ctx context.Context) (*exploreView, error) { return e.prefixesView(ctx, asNumber) }},
+			{label: "peers", open: func(ctx context.Context) (*exploreView, error) { return e.peersView(ctx, asNumber) }},
+			{label: "upstreams", open: func(ctx context.Context) (*exploreView, error) { return e.upstreamsView(ctx, asNumber) }},
+			{label: "downstreams", open: func(ctx context.Context) (*exploreView, error) { return e.downstreamsView(ctx, asNumber) }},
+			{label: "IXs", open: func(ctx context.Context) (*exploreView, error) { return e.ixsView(ctx, asNumber) }},
+		},
+	}, nil
+}
+
+func (e *explorer) prefixesView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASNPrefixes(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &exploreView{title: "prefixes"}
+
+	for _, prefixes := range [][]bgpview.ASNIPPrefixesData{info.Data.IPv4Prefixes, info.Data.IPv6Prefixes} {
+		for _, prefix := range prefixes {
+			prefix := prefix
+
+			view.items = append(view.items, exploreItem{
+				label: strings.Join([]string{prefix.Prefix, prefix.Name, prefix.Description, prefix.CountryCode}, "\t"),
+				open: func(ctx context.Context) (*exploreView, error) {
+					return e.prefixView(ctx, prefix.IP, prefix.CIDR)
+				},
+			})
+		}
+	}
+
+	return view, nil
+}
+
+func (e *explorer) peersView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASNPeers(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &exploreView{title: "peers"}
+
+	for _, peer := range info.Data.IPv4Peers {
+		view.items = append(view.items, e.asnItem("IPv4", peer.ASN, peer.Name, peer.Description, peer.CountryCode))
+	}
+
+	for _, peer := range info.Data.IPv6Peers {
+		view.items = append(view.items, e.asnItem("IPv6", peer.ASN, peer.Name, peer.Description, peer.CountryCode))
+	}
+
+	return view, nil
+}
+
+func (e *explorer) upstreamsView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASNUpstreams(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &exploreView{title: "upstreams"}
+
+	for _, upstream := range info.Data.IPv4Upstreams {
+		view.items = append(view.items, e.asnItem("IPv4", upstream.ASN, upstream.Name, upstream.Description, upstream.CountryCode))
+	}
+
+	for _, upstream := range info.Data.IPv6Upstreams {
+		view.items = append(view.items, e.asnItem("IPv6", upstream.ASN, upstream.Name, upstream.Description, upstream.CountryCode))
+	}
+
+	return view, nil
+}
+
+func (e *explorer) downstreamsView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASNDownstreams(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &exploreView{title: "downstreams"}
+
+	for _, downstream := range info.Data.IPv4Downstreams {
+		view.items = append(view.items, e.asnItem("IPv4", downstream.ASN, downstream.Name, downstream.Description, downstream.CountryCode))
+	}
+
+	for _, downstream := range info.Data.IPv6Downstreams {
+		view.items = append(view.items, e.asnItem("IPv6", downstream.ASN, downstream.Name, downstream.Description, downstream.CountryCode))
+	}
+
+	return view, nil
+}
+
+func (e *explorer) ixsView(ctx context.Context, asNumber int) (*exploreView, error) {
+	info, err := e.client.GetASNIxs(ctx, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &exploreView{title: "IXs"}
+
+	for _, ix := range info.Data {
+		ixID := ix.IxID
+
+		view.items = append(view.items, exploreItem{
+			label: strings.Join([]string{ix.Name, ix.City, ix.CountryCode, ix.IPv4Address, ix.IPv6Address, ix.PortSpeed().String()}, "\t"),
+			open: func(ctx context.Context) (*exploreView, error) {
+				return e.ixView(ctx, ixID)
+			},
+		})
+	}
+
+	return view, nil
+}
+
+func (e *explorer) prefixView(ctx context.Context, ip string, cidr int) (*exploreView, error) {
+	info, err := e.client.GetPrefix(ctx, ip, cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	data := info.Data
+
+	view := &exploreView{
+		title: fmt.Sprintf("%s/%d", ip, cidr),
+		details: [][2]string{
+			{"name", data.Name},
+			{"description", data.DescriptionShort},
+			{"country", data.CountryCode},
+			{"abuse", strings.Join(data.AbuseContacts, ", ")},
+			{"RIR", string(data.RIRAllocation.RIRName)},
+			{"allocation", data.RIRAllocation.Prefix},
+			{"allocated", data.RIRAllocation.DateAllocated.String()},
+			{"updated", data.DateUpdated.String()},
+		},
+	}
+
+	for _, asn := range data.ASNs {
+		view.items = append(view.items, e.asnItem("origin", asn.ASN, asn.Name, asn.Description, asn.CountryCode))
+	}
+
+	return view, nil
+}
+
+func (e *explorer) ixView(ctx context.Context, ixID int) (*exploreView, error) {
+	info, err := e.client.GetIX(ctx, ixID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := info.Data
+
+	view := &exploreView{
+		title: fmt.Sprintf("IX %d", ixID),
+		details: [][2]string{
+			{"name", data.Name},
+			{"full name", data.NameFull},
+			{"city", data.City},
+			{"country", data.CountryCode},
+			{"website", data.Website},
+			{"tech email", data.TechEmail},
+			{"members", strconv.Itoa(data.MembersCount)},
+		},
+	}
+
+	for _, member := range data.Members {
+		view.items = append(view.items, e.asnItem("member", member.ASN, member.Name, member.Description, member.CountryCode))
+	}
+
+	return view, nil
+}
+
+// asnItem is an item opening an ASN.
+func (e *explorer) asnItem(kind string, asNumber int, name, description, countryCode string) exploreItem {
+	return exploreItem{
+		label: strings.Join([]string{kind, fmt.Sprintf("AS%d", asNumber), name, description, countryCode}, "\t"),
+		open: func(ctx context.Context) (*exploreView, error) {
+			return e.asnView(ctx, asNumber)
+		},
+	}
+}
@@ -0,0 +1,199 @@
+// Command bgpview queries the BGPView API.
+//
+// Usage:
+//
+//	bgpview [flags] <command> <argument>
+//
+// Examples:
+//
+//	bgpview asn AS61138
+//	bgpview prefixes 61138
+//	bgpview prefix 192.209.63.0/24
+//	bgpview ip 2a05:dfc7:60::
+//	bgpview ix 492
+//	bgpview search digitalocean
+//	bgpview lookup 45.67.13.7
+//	bgpview -format table prefixes AS61138
+//	bgpview -format csv enrich - < ips.txt
+//	bgpview explore AS61138
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/electrologue/bgpview"
+	"github.com/electrologue/bgpview/render"
+)
+
+// Exit codes.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitNotFound    = 3
+	exitRateLimited = 4
+	exitNetwork     = 5
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	go func() {
+		<-ctx.Done()
+		// Restore the default behavior: a second interrupt kills the process.
+		stop()
+	}()
+
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
+
+type config struct {
+	baseURL   string
+	timeout   time.Duration
+	retries   int
+	userAgent string
+	format    string
+	cache     bgpview.Cache
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cfg := config{}
+
+	flags := flag.NewFlagSet("bgpview", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&cfg.baseURL, "base-url", "", "base URL of the API (default https://api.bgpview.io)")
+	flags.DurationVar(&cfg.timeout, "timeout", 10*time.Second, "timeout of each HTTP request")
+	flags.IntVar(&cfg.retries, "retries", 0, "number of retries on rate limiting, server and network errors")
+	flags.StringVar(&cfg.userAgent, "user-agent", "", "User-Agent header")
+	flags.StringVar(&cfg.format, "format", string(render.FormatJSON), "output format: table, json, ndjson, csv or yaml")
+	flags.Usage = func() { usage(flags) }
+
+	err := flags.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+
+		return exitUsage
+	}
+
+	if flags.NArg() != 2 {
+		usage(flags)
+		return exitUsage
+	}
+
+	format, err := render.ParseFormat(cfg.format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	cmd, ok := findCommand(flags.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command: %s\n\n", flags.Arg(0))
+		usage(flags)
+
+		return exitUsage
+	}
+
+	if cmd.sessionCache {
+		cfg.cache = bgpview.NewMemoryCache(sessionCacheSize)
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	if cmd.stream != nil {
+		err = cmd.stream(ctx, client, flags.Arg(1), streamIO{stdin: stdin, stdout: stdout, format: format})
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitCode(err)
+		}
+
+		return exitOK
+	}
+
+	result, err := cmd.run(ctx, client, flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitCode(err)
+	}
+
+	err = render.Render(stdout, format, result)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func newClient(cfg config) (*bgpview.Client, error) {
+	opts := []bgpview.Option{bgpview.WithTimeout(cfg.timeout)}
+
+	if cfg.baseURL != "" {
+		opts = append(opts, bgpview.WithBaseURL(cfg.baseURL))
+	}
+
+	if cfg.userAgent != "" {
+		opts = append(opts, bgpview.WithUserAgent(cfg.userAgent))
+	}
+
+	if cfg.cache != nil {
+		opts = append(opts, bgpview.WithCache(cfg.cache, nil))
+	}
+
+	if cfg.retries > 0 {
+		policy := bgpview.DefaultRetryPolicy()
+		policy.MaxAttempts = cfg.retries + 1
+
+		opts = append(opts, bgpview.WithRetryPolicy(policy))
+	}
+
+	return bgpview.NewClient(opts...)
+}
+
+func usage(flags *flag.FlagSet) {
+	out := flags.Output()
+
+	fmt.Fprintf(out, "Usage: %s [flags] <command> <argument>\n\nCommands:\n", flags.Name())
+
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-12s %-10s %s\n", cmd.name, cmd.arg, cmd.summary)
+	}
+
+	fmt.Fprintf(out, "\nFlags:\n")
+	flags.PrintDefaults()
+
+	fmt.Fprintf(out, "\nExit codes:\n")
+	fmt.Fprintf(out, "  %d  success\n", exitOK)
+	fmt.Fprintf(out, "  %d  error\n", exitError)
+	fmt.Fprintf(out, "  %d  invalid usage\n", exitUsage)
+	fmt.Fprintf(out, "  %d  not found\n", exitNotFound)
+	fmt.Fprintf(out, "  %d  rate limited\n", exitRateLimited)
+	fmt.Fprintf(out, "  %d  network error\n", exitNetwork)
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if strings.EqualFold(cmd.name, name) {
+			return cmd, true
+		}
+	}
+
+	return command{}, false
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/electrologue/bgpview/bgpviewtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTest(t *testing.T, server *bgpviewtest.Server, args ...string) (int, string, string) {
+	t.Helper()
+
+	return runTestInput(t, server, "", args...)
+}
+
+func runTestInput(t *testing.T, server *bgpviewtest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), append([]string{"-base-url", server.URL}, args...), strings.NewReader(stdin), &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_run(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+
+	testCases := []struct {
+		args    []string
+		fixture string
+	}{
+		{args: []string{"asn", "AS61138"}, fixture: "asn.json"},
+		{args: []string{"prefixes", "as61138"}, fixture: "asn-prefixes.json"},
+		{args: []string{"peers", "61138"}, fixture: "asn-peers.json"},
+		{args: []string{"upstreams", "AS61138"}, fixture: "asn-upstreams.json"},
+		{args: []string{"downstreams", "AS61138"}, fixture: "asn-downstreams.json"},
+		{args: []string{"ixs", "AS61138"}, fixture: "asn-ixs.json"},
+		{args: []string{"prefix", "192.209.63.0/24"}, fixture: "prefix.json"},
+		{args: []string{"ip", "2a05:dfc7:60::"}, fixture: "ip.json"},
+		{args: []string{"ix", "492"}, fixture: "ix.json"},
+		{args: []string{"search", "digitalocean"}, fixture: "search.json"},
+		{args: []string{"lookup", "AS61138"}, fixture: "asn.json"},
+		{args: []string{"lookup", "192.209.63.0/24"}, fixture: "prefix.json"},
+		{args: []string{"lookup", "IX492"}, fixture: "ix.json"},
+		{args: []string{"lookup", "digitalocean"}, fixture: "search.json"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.args[0], func(t *testing.T) {
+			t.Parallel()
+
+			code, stdout, stderr := runTest(t, server, test.args...)
+
+			assert.Equal(t, exitOK, code)
+			assert.Empty(t, stderr)
+			assert.JSONEq(t, string(bgpviewtest.Fixture(test.fixture)), stdout)
+		})
+	}
+}
+
+func Test_run_exitCodes(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+	server.SetError("/asn/2", http.StatusTooManyRequests, "Too Many Requests")
+	server.SetError("/ix/3", http.StatusInternalServerError, "Internal Server Error")
+
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected int
+	}{
+		{desc: "not found", args: []string{"asn", "AS1"}, expected: exitNotFound},
+		{desc: "rate limited", args: []string{"asn", "AS2"}, expected: exitRateLimited},
+		{desc: "server error", args: []string{"ix", "3"}, expected: exitError},
+		{desc: "invalid ASN", args: []string{"asn", "ASX"}, expected: exitUsage},
+		{desc: "invalid prefix", args: []string{"prefix", "192.209.63.0"}, expected: exitUsage},
+		{desc: "invalid IP", args: []string{"ip", "192.209.63.0/24"}, expected: exitUsage},
+		{desc: "invalid IX", args: []string{"ix", "-1"}, expected: exitUsage},
+		{desc: "unknown command", args: []string{"whois", "AS61138"}, expected: exitUsage},
+		{desc: "missing argument", args: []string{"asn"}, expected: exitUsage},
+		{desc: "empty query", args: []string{"lookup", " "}, expected: exitUsage},
+		{desc: "lookup not found", args: []string{"lookup", "AS1"}, expected: exitNotFound},
+		{desc: "unknown flag", args: []string{"-verbose", "asn", "AS61138"}, expected: exitUsage},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			code, stdout, stderr := runTest(t, server, test.args...)
+
+			assert.Equal(t, test.expected, code)
+			assert.Empty(t, stdout)
+			assert.NotEmpty(t, stderr)
+		})
+	}
+}
+
+func Test_run_networkError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"-base-url", "http://127.0.0.1:1", "asn", "AS61138"}, strings.NewReader(""), &stdout, &stderr)
+
+	assert.Equal(t, exitNetwork, code)
+	assert.Contains(t, stderr.String(), "connection refused")
+}
+
+func Test_run_retries(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+	server.SetError("/asn/2", http.StatusTooManyRequests, "Too Many Requests")
+
+	code, _, _ := runTest(t, server, "-retries", "1", "asn", "AS2")
+
+	assert.Equal(t, exitRateLimited, code)
+	assert.Equal(t, 2, server.Requests("/asn/2"))
+}
+
+func Test_run_help(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+
+	code, stdout, stderr := runTest(t, server, "-h")
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Commands:")
+}
+
+func Test_parseASN(t *testing.T) {
+	for value, expected := range map[string]int{"61138": 61138, "AS61138": 61138, "as61138": 61138, " AS1 ": 1, "4294967295": 4294967295} {
+		asNumber, err := parseASN(value)
+		require.NoError(t, err, value)
+
+		assert.Equal(t, expected, asNumber, value)
+	}
+
+	for _, value := range []string{"", "AS", "0", "AS-1", "4294967296", "ASN61138"} {
+		_, err := parseASN(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func Test_run_format(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+
+	code, stdout, stderr := runTest(t, server, "-format", "csv", "prefixes", "AS61138")
+
+	require.Equal(t, exitOK, code, stderr)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Equal(t, "section,prefix,ip,cidr,roa_status,name,description,country_code,parent.prefix,parent.ip,parent.cidr,parent.rir_name,parent.allocation_status", lines[0])
+	assert.Len(t, lines, 43)
+
+	code, _, stderr = runTest(t, server, "-format", "xml", "asn", "AS61138")
+
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "unknown format \"xml\"\n", stderr)
+}
+
+func Test_run_enrich(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+
+	input := "2a05:dfc7:60::\nAS61138\nas61138\n192.0.2.1\n"
+
+	code, stdout, stderr := runTestInput(t, server, input, "-format", "csv", "enrich", "-")
+
+	require.Equal(t, exitOK, code, stderr)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, "line,input,ip,prefix,asn,asn_name,country_code,abuse_contacts,error", lines[0])
+	assert.Equal(t, "1,2a05:dfc7:60::,2a05:dfc7:60::,2a05:dfc0::/29,61138,ZAPPIE-HOST-AS,GB,abuse@zappiehost.com,", lines[1])
+	assert.Equal(t, "2,AS61138,,,61138,ZAPPIE-HOST-AS,US,abuse@zappiehost.com,", lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "4,192.0.2.1,192.0.2.1,,0,,,,bgpview: 404:"), lines[3])
+
+	code, stdout, _ = runTestInput(t, server, input, "enrich", "-")
+
+	require.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 3, "NDJSON")
+
+	code, _, stderr = runTestInput(t, server, input, "-format", "table", "enrich", "-")
+
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "format \"table\" cannot be streamed\n", stderr)
+}
+
+func Test_run_explore(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+	server.SetFixture("/prefix/45.67.13.0/24", "prefix.json")
+
+	input := strings.Join([]string{
+		"1",     // prefixes
+		"n",     // page 2
+		"1",     // 45.67.13.0/24
+		"b",     // prefixes
+		"b",     // AS61138
+		"5",     // IXs
+		"9",     // no such item
+		"b",     // AS61138
+		"1",     // prefixes again, from the cache
+		"asn x", // invalid ASN
+		"ix 1",  // not found
+		"q",
+	}, "\n")
+
+	code, stdout, stderr := runTestInput(t, server, input, "explore", "AS61138")
+
+	require.Equal(t, exitOK, code, stderr)
+
+	assert.Contains(t, stdout, "AS61138 / prefixes / 45.67.13.0/24\n")
+	assert.Contains(t, stdout, "page 2/3")
+	assert.Contains(t, stdout, "  1  origin  AS1239  SPRINTLINK  Sprint  US\n")
+	assert.Contains(t, stdout, "AS61138 / IXs> no item 9\n")
+	assert.Contains(t, stdout, "error: invalid ASN \"x\"\n")
+	assert.Contains(t, stdout, "error: bgpview: 404")
+
+	assert.Equal(t, 1, server.Requests(bgpviewtest.RouteASN))
+	assert.Equal(t, 1, server.Requests(bgpviewtest.RouteASNPrefixes))
+
+	code, _, stderr = runTestInput(t, server, "q\n", "explore", "AS1")
+
+	assert.Equal(t, exitNotFound, code)
+	assert.NotEmpty(t, stderr)
+}
+
+func Test_run_explore_interrupt(t *testing.T) {
+	server := bgpviewtest.NewServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The input is never written to, as a terminal waiting for the user.
+	stdin, writer := io.Pipe()
+	t.Cleanup(func() { _ = writer.Close() })
+
+	stdout := &lockedBuffer{}
+	done := make(chan int)
+
+	go func() {
+		var stderr bytes.Buffer
+		done <- run(ctx, []string{"-base-url", server.URL, "explore", "AS61138"}, stdin, stdout, &stderr)
+	}()
+
+	require.Eventually(t, func() bool { return strings.HasSuffix(stdout.String(), "AS61138> ") }, time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	select {
+	case code := <-done:
+		assert.NotEqual(t, exitOK, code)
+	case <-time.After(time.Second):
+		t.Fatal("the explorer is still waiting for the input")
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
@@ -0,0 +1,318 @@
+package bgpview
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enrichWindow is the number of pending lookups by worker, bounding the memory used to keep the input order.
+const enrichWindow = 16
+
+// Enrichment is the enrichment of an IP address or an ASN read by Enrich.
+type Enrichment struct {
+	// Line is the line number of the first occurrence of the identifier.
+	Line int `json:"line"`
+	// Input is the identifier as read (e.g. "AS61138", "192.0.2.1").
+	Input string `json:"input"`
+
+	IP            string   `json:"ip,omitempty"`
+	Prefix        string   `json:"prefix,omitempty"`
+	ASN           int      `json:"asn,omitempty"`
+	ASNName       string   `json:"asn_name,omitempty"`
+	CountryCode   string   `json:"country_code,omitempty"`
+	AbuseContacts []string `json:"abuse_contacts,omitempty"`
+
+	// Err is the error of the lookup, if any.
+	// The other fields keep what was resolved before the error.
+	Err error `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the error as its message.
+func (e Enrichment) MarshalJSON() ([]byte, error) {
+	type plain Enrichment
+
+	var message string
+	if e.Err != nil {
+		message = e.Err.Error()
+	}
+
+	return json.Marshal(struct {
+		plain
+		Err string `json:"error,omitempty"`
+	}{plain: plain(e), Err: message})
+}
+
+// Enrich reads IP addresses and ASNs (e.g. "192.0.2.1", "2001:db8::1", "AS61138", "61138") line by line,
+// and calls fn with the enrichment of each distinct identifier, in the order of their first occurrence.
+// Blank lines and lines starting with "#" are skipped.
+//
+// The IP addresses are enriched with their most specific prefix, its origin ASN and country,
+// and the abuse contacts of the ASN.
+// The ASNs are enriched with their name, country and abuse contacts.
+// Each ASN is looked up once, and the lookups run concurrently (see BatchConcurrency).
+//
+// A failed lookup (e.g. ErrNotFound, an invalid identifier) doesn't stop Enrich: its error is in Enrichment.Err.
+// Enrich stops on the first error of the reader or of fn, or when the context is done.
+func (c Client) Enrich(ctx context.Context, r io.Reader, fn func(e *Enrichment) error) error {
+	workers := c.BatchConcurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	enricher := &enricher{client: c, asns: make(map[int]*asnLookup)}
+
+	jobs := make(chan *pendingEnrichment)
+	ordered := make(chan *pendingEnrichment, workers*enrichWindow)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for pending := range jobs {
+				enricher.enrich(runCtx, pending)
+				close(pending.done)
+			}
+		}()
+	}
+
+	var readErr error
+
+	go func() {
+		defer close(ordered)
+		defer close(jobs)
+
+		readErr = readIdentifiers(runCtx, r, ordered, jobs)
+	}()
+
+	var err error
+
+	for pending := range ordered {
+		<-pending.done
+
+		if err != nil || runCtx.Err() != nil {
+			continue
+		}
+
+		err = fn(&pending.enrichment)
+		if err != nil {
+			cancel()
+		}
+	}
+
+	wg.Wait()
+
+	switch {
+	case err != nil:
+		return err
+	case ctx.Err() != nil:
+		return ctx.Err()
+	default:
+		return readErr
+	}
+}
+
+type pendingEnrichment struct {
+	enrichment Enrichment
+	addr       netip.Addr
+	asNumber   int
+	done       chan struct{}
+}
+
+// readIdentifiers sends each distinct identifier to ordered, then to jobs.
+// The identifiers not sent to jobs are done with the context error.
+func readIdentifiers(ctx context.Context, r io.Reader, ordered, jobs chan<- *pendingEnrichment) error {
+	scanner := bufio.NewScanner(r)
+	seen := make(map[string]struct{})
+
+	for line := 1; scanner.Scan(); line++ {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" || strings.HasPrefix(input, "#") {
+			continue
+		}
+
+		pending := &pendingEnrichment{
+			enrichment: Enrichment{Line: line, Input: input},
+			done:       make(chan struct{}),
+		}
+
+		key := pending.parse()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		select {
+		case ordered <- pending:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		select {
+		case jobs <- pending:
+		case <-ctx.Done():
+			pending.enrichment.Err = ctx.Err()
+			close(pending.done)
+
+			return ctx.Err()
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parse parses the identifier, and returns its deduplication key.
+func (p *pendingEnrichment) parse() string {
+	input := p.enrichment.Input
+
+	addr, err := netip.ParseAddr(input)
+	if err == nil {
+		p.addr = addr.Unmap().WithZone("")
+		return p.addr.String()
+	}
+
+	value := input
+	if len(value) > 2 && strings.EqualFold(value[:2], "AS") {
+		value = value[2:]
+	}
+
+	asNumber, err := strconv.ParseUint(value, 10, 32)
+	if err == nil && asNumber > 0 {
+		p.asNumber = int(asNumber)
+		return "AS" + strconv.Itoa(p.asNumber)
+	}
+
+	p.enrichment.Err = fmt.Errorf("invalid IP address or ASN: %q", input)
+
+	return input
+}
+
+// enricher looks up the identifiers, each ASN being looked up once.
+type enricher struct {
+	client Client
+
+	mu   sync.Mutex
+	asns map[int]*asnLookup
+}
+
+type asnLookup struct {
+	done chan struct{}
+	info *ASNInfo
+	err  error
+}
+
+func (e *enricher) enrich(ctx context.Context, pending *pendingEnrichment) {
+	enrichment := &pending.enrichment
+
+	switch {
+	case enrichment.Err != nil:
+		return
+
+	case pending.addr.IsValid():
+		e.enrichIP(ctx, enrichment, pending.addr)
+
+	default:
+		info, err := e.getASN(ctx, pending.asNumber)
+		if err != nil {
+			enrichment.Err = err
+			return
+		}
+
+		enrichment.ASN = info.Data.ASN
+		enrichment.ASNName = info.Data.Name
+		enrichment.CountryCode = info.Data.CountryCode
+		enrichment.AbuseContacts = info.Data.AbuseContacts
+	}
+}
+
+func (e *enricher) enrichIP(ctx context.Context, enrichment *Enrichment, addr netip.Addr) {
+	enrichment.IP = addr.String()
+
+	info, err := e.client.GetNetIP(ctx, addr)
+	if err != nil {
+		enrichment.Err = err
+		return
+	}
+
+	enrichment.CountryCode = info.Data.MaxMind.CountryCode
+
+	var prefix *PrefixData
+
+	for i := range info.Data.Prefixes {
+		if prefix == nil || info.Data.Prefixes[i].CIDR > prefix.CIDR {
+			prefix = &info.Data.Prefixes[i]
+		}
+	}
+
+	if prefix == nil {
+		return
+	}
+
+	enrichment.Prefix = prefix.Prefix
+	enrichment.ASN = prefix.ASN.ASN
+	enrichment.ASNName = prefix.ASN.Name
+
+	if prefix.CountryCode != "" {
+		enrichment.CountryCode = prefix.CountryCode
+	}
+
+	if enrichment.ASN == 0 {
+		return
+	}
+
+	asnInfo, err := e.getASN(ctx, enrichment.ASN)
+	if err != nil {
+		enrichment.Err = fmt.Errorf("lookup of AS%d: %w", enrichment.ASN, err)
+		return
+	}
+
+	enrichment.AbuseContacts = asnInfo.Data.AbuseContacts
+}
+
+// getASN looks up an ASN once, the concurrent callers waiting for the first lookup.
+// A lookup failed because of a context error is retried by the next caller.
+func (e *enricher) getASN(ctx context.Context, asNumber int) (*ASNInfo, error) {
+	e.mu.Lock()
+
+	lookup, ok := e.asns[asNumber]
+	if !ok {
+		lookup = &asnLookup{done: make(chan struct{})}
+		e.asns[asNumber] = lookup
+	}
+
+	e.mu.Unlock()
+
+	if ok {
+		select {
+		case <-lookup.done:
+			if !errors.Is(lookup.err, context.Canceled) && !errors.Is(lookup.err, context.DeadlineExceeded) {
+				return lookup.info, lookup.err
+			}
+
+			return e.client.GetASN(ctx, asNumber)
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	lookup.info, lookup.err = e.client.GetASN(ctx, asNumber)
+	close(lookup.done)
+
+	return lookup.info, lookup.err
+}
@@ -0,0 +1,151 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectEnrichments(t *testing.T, client *Client, input string) []Enrichment {
+	t.Helper()
+
+	var enrichments []Enrichment
+
+	err := client.Enrich(context.Background(), strings.NewReader(input), func(e *Enrichment) error {
+		enrichments = append(enrichments, *e)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return enrichments
+}
+
+func TestClient_Enrich(t *testing.T) {
+	client, mux := setupTest(t)
+
+	var asnRequests int32
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip.json"))
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&asnRequests, 1)
+		testHandler("asn.json")(rw, req)
+	})
+
+	input := "# firewall\n2a05:dfc7:60::\n\nAS61138\n 61138 \n2a05:dfc7:0060::\n192.0.2.1\nexample.com\n"
+
+	enrichments := collectEnrichments(t, client, input)
+	require.Len(t, enrichments, 4)
+
+	abuse := []string{"abuse@zappiehost.com"}
+
+	assert.Equal(t, Enrichment{
+		Line:          2,
+		Input:         "2a05:dfc7:60::",
+		IP:            "2a05:dfc7:60::",
+		Prefix:        "2a05:dfc0::/29",
+		ASN:           61138,
+		ASNName:       "ZAPPIE-HOST-AS",
+		CountryCode:   "GB",
+		AbuseContacts: abuse,
+	}, enrichments[0])
+
+	assert.Equal(t, Enrichment{
+		Line:          4,
+		Input:         "AS61138",
+		ASN:           61138,
+		ASNName:       "ZAPPIE-HOST-AS",
+		CountryCode:   "US",
+		AbuseContacts: abuse,
+	}, enrichments[1])
+
+	assert.Equal(t, 7, enrichments[2].Line)
+	assert.Equal(t, "192.0.2.1", enrichments[2].IP)
+	assert.ErrorIs(t, enrichments[2].Err, ErrNotFound)
+
+	assert.Equal(t, 8, enrichments[3].Line)
+	assert.EqualError(t, enrichments[3].Err, `invalid IP address or ASN: "example.com"`)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&asnRequests))
+}
+
+func TestClient_Enrich_order(t *testing.T) {
+	client, mux := setupTest(t)
+	client.BatchConcurrency = 8
+
+	mux.HandleFunc("/asn/", func(rw http.ResponseWriter, req *http.Request) {
+		asNumber, _ := strconv.Atoi(strings.TrimPrefix(req.URL.Path, "/asn/"))
+
+		// The first ASNs are the slowest.
+		time.Sleep(time.Duration(200-asNumber) * 50 * time.Microsecond)
+
+		testHandler("asn.json")(rw, req)
+	})
+
+	var input strings.Builder
+	for i := 1; i <= 200; i++ {
+		fmt.Fprintf(&input, "AS%d\n", i)
+	}
+
+	enrichments := collectEnrichments(t, client, input.String())
+	require.Len(t, enrichments, 200)
+
+	for i, enrichment := range enrichments {
+		assert.Equal(t, i+1, enrichment.Line)
+		assert.Equal(t, fmt.Sprintf("AS%d", i+1), enrichment.Input)
+		assert.NoError(t, enrichment.Err)
+	}
+}
+
+func TestClient_Enrich_callbackError(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/", testHandler("asn.json"))
+
+	errStop := errors.New("stop")
+
+	calls := 0
+
+	err := client.Enrich(context.Background(), strings.NewReader("AS1\nAS2\nAS3\nAS4\n"), func(e *Enrichment) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_Enrich_canceled(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/", testHandler("asn.json"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := client.Enrich(ctx, strings.NewReader("AS1\nAS2\nAS3\nAS4\n"), func(e *Enrichment) error {
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEnrichment_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Enrichment{Line: 3, Input: "192.0.2.1", IP: "192.0.2.1", Err: ErrNotFound})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, fmt.Sprintf(`{"line":3,"input":"192.0.2.1","ip":"192.0.2.1","error":%q}`, ErrNotFound.Error()), string(data))
+
+	data, err = json.Marshal(&Enrichment{Line: 1, Input: "AS1", ASN: 1})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"line":1,"input":"AS1","asn":1}`, string(data))
+}
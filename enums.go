@@ -0,0 +1,155 @@
+package bgpview
+
+import "strings"
+
+// RIR is a Regional Internet Registry name.
+// Values that aren't constants are preserved as returned by the API: Known reports false for them.
+type RIR string
+
+// Regional Internet Registries.
+const (
+	RIRAfriNIC RIR = "AfriNIC"
+	RIRAPNIC   RIR = "APNIC"
+	RIRARIN    RIR = "ARIN"
+	RIRLACNIC  RIR = "LACNIC"
+	RIRRIPE    RIR = "RIPE"
+)
+
+var rirs = []RIR{RIRAfriNIC, RIRAPNIC, RIRARIN, RIRLACNIC, RIRRIPE}
+
+// UnmarshalText implements encoding.TextUnmarshaler (case-insensitive).
+func (r *RIR) UnmarshalText(text []byte) error {
+	*r = canonicalEnum(string(text), rirs)
+	return nil
+}
+
+// Known reports whether the value is one of the constants.
+func (r RIR) Known() bool {
+	return knownEnum(r, rirs)
+}
+
+func (r RIR) String() string {
+	return string(r)
+}
+
+// ROAStatus is the RPKI Route Origin Authorization status of a prefix.
+// Values that aren't constants are preserved as returned by the API: Known reports false for them.
+type ROAStatus string
+
+// ROA statuses.
+const (
+	ROAStatusNone    ROAStatus = "None"
+	ROAStatusValid   ROAStatus = "Valid"
+	ROAStatusInvalid ROAStatus = "Invalid"
+)
+
+var roaStatuses = []ROAStatus{ROAStatusNone, ROAStatusValid, ROAStatusInvalid}
+
+// UnmarshalText implements encoding.TextUnmarshaler (case-insensitive).
+func (s *ROAStatus) UnmarshalText(text []byte) error {
+	*s = canonicalEnum(string(text), roaStatuses)
+	return nil
+}
+
+// Known reports whether the value is one of the constants.
+func (s ROAStatus) Known() bool {
+	return knownEnum(s, roaStatuses)
+}
+
+func (s ROAStatus) String() string {
+	return string(s)
+}
+
+// TrafficRatio is the inbound/outbound traffic ratio of an ASN.
+// Values that aren't constants are preserved as returned by the API: Known reports false for them.
+type TrafficRatio string
+
+// Traffic ratios.
+const (
+	TrafficRatioNotDisclosed   TrafficRatio = "Not Disclosed"
+	TrafficRatioHeavyOutbound  TrafficRatio = "Heavy Outbound"
+	TrafficRatioMostlyOutbound TrafficRatio = "Mostly Outbound"
+	TrafficRatioBalanced       TrafficRatio = "Balanced"
+	TrafficRatioMostlyInbound  TrafficRatio = "Mostly Inbound"
+	TrafficRatioHeavyInbound   TrafficRatio = "Heavy Inbound"
+)
+
+var trafficRatios = []TrafficRatio{
+	TrafficRatioNotDisclosed, TrafficRatioHeavyOutbound, TrafficRatioMostlyOutbound,
+	TrafficRatioBalanced, TrafficRatioMostlyInbound, TrafficRatioHeavyInbound,
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler (case-insensitive).
+func (r *TrafficRatio) UnmarshalText(text []byte) error {
+	*r = canonicalEnum(string(text), trafficRatios)
+	return nil
+}
+
+// Known reports whether the value is one of the constants.
+func (r TrafficRatio) Known() bool {
+	return knownEnum(r, trafficRatios)
+}
+
+func (r TrafficRatio) String() string {
+	return string(r)
+}
+
+// AllocationStatus is the allocation status of a resource in the RIR or IANA registries.
+// Values that aren't constants are preserved as returned by the API: Known reports false for them.
+// Not to be confused with AllocationStatusUnknown, which is a status returned by the API.
+type AllocationStatus string
+
+// Allocation statuses.
+const (
+	AllocationStatusAllocated AllocationStatus = "allocated"
+	AllocationStatusAssigned  AllocationStatus = "assigned"
+	AllocationStatusAvailable AllocationStatus = "available"
+	AllocationStatusReserved  AllocationStatus = "reserved"
+	// AllocationStatusUnknown is the literal "unknown" status returned by the API (e.g. for the parent of a prefix).
+	// It is a known value: the values that aren't constants are detected with Known.
+	AllocationStatusUnknown AllocationStatus = "unknown"
+)
+
+var allocationStatuses = []AllocationStatus{
+	AllocationStatusAllocated, AllocationStatusAssigned, AllocationStatusAvailable,
+	AllocationStatusReserved, AllocationStatusUnknown,
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler (case-insensitive).
+func (s *AllocationStatus) UnmarshalText(text []byte) error {
+	*s = canonicalEnum(string(text), allocationStatuses)
+	return nil
+}
+
+// Known reports whether the value is one of the constants.
+func (s AllocationStatus) Known() bool {
+	return knownEnum(s, allocationStatuses)
+}
+
+func (s AllocationStatus) String() string {
+	return string(s)
+}
+
+// canonicalEnum returns the constant matching a raw value, ignoring the case and the surrounding spaces,
+// or the raw value if there is no match.
+func canonicalEnum[T ~string](raw string, values []T) T {
+	trimmed := strings.TrimSpace(raw)
+
+	for _, value := range values {
+		if strings.EqualFold(trimmed, string(value)) {
+			return value
+		}
+	}
+
+	return T(raw)
+}
+
+func knownEnum[T ~string](v T, values []T) bool {
+	for _, value := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
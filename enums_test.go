@@ -0,0 +1,95 @@
+package bgpview
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRIR_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected RIR
+		known    bool
+	}{
+		{data: `"RIPE"`, expected: RIRRIPE, known: true},
+		{data: `"ripe"`, expected: RIRRIPE, known: true},
+		{data: `"AFRINIC"`, expected: RIRAfriNIC, known: true},
+		{data: `" Arin "`, expected: RIRARIN, known: true},
+		{data: `"IANA"`, expected: RIR("IANA")},
+		{data: `null`, expected: RIR("")},
+		{data: `""`, expected: RIR("")},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.data, func(t *testing.T) {
+			t.Parallel()
+
+			var rir RIR
+
+			err := json.Unmarshal([]byte(test.data), &rir)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, rir)
+			assert.Equal(t, test.known, rir.Known())
+		})
+	}
+}
+
+func TestROAStatus_UnmarshalJSON(t *testing.T) {
+	var data struct {
+		Statuses []ROAStatus `json:"statuses"`
+	}
+
+	err := json.Unmarshal([]byte(`{"statuses": ["None", "valid", "INVALID", "Unverified"]}`), &data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ROAStatus{ROAStatusNone, ROAStatusValid, ROAStatusInvalid, "Unverified"}, data.Statuses)
+	assert.False(t, data.Statuses[3].Known())
+	assert.Equal(t, "Unverified", data.Statuses[3].String())
+}
+
+func TestTrafficRatio_UnmarshalJSON(t *testing.T) {
+	var data ASNData
+
+	err := json.Unmarshal([]byte(`{"traffic_ratio": "mostly outbound"}`), &data)
+	require.NoError(t, err)
+
+	assert.Equal(t, TrafficRatioMostlyOutbound, data.TrafficRatio)
+	assert.True(t, data.TrafficRatio.Known())
+	assert.Equal(t, "Mostly Outbound", data.TrafficRatio.String())
+}
+
+func TestAllocationStatus_UnmarshalJSON(t *testing.T) {
+	var data AllocationData
+
+	err := json.Unmarshal([]byte(`{"rir_name": "AfriNIC", "allocation_status": "Allocated"}`), &data)
+	require.NoError(t, err)
+
+	assert.Equal(t, RIRAfriNIC, data.RIRName)
+	assert.Equal(t, AllocationStatusAllocated, data.AllocationStatus)
+
+	err = json.Unmarshal([]byte(`{"allocation_status": "legacy"}`), &data)
+	require.NoError(t, err)
+
+	assert.Equal(t, AllocationStatus("legacy"), data.AllocationStatus)
+	assert.False(t, data.AllocationStatus.Known())
+	assert.NotEqual(t, AllocationStatusUnknown, data.AllocationStatus)
+
+	// "unknown" is a status of the API, not the fallback.
+	err = json.Unmarshal([]byte(`{"allocation_status": "Unknown"}`), &data)
+	require.NoError(t, err)
+
+	assert.Equal(t, AllocationStatusUnknown, data.AllocationStatus)
+	assert.True(t, data.AllocationStatus.Known())
+}
+
+func TestRIR_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(SearchASNData{ASN: 61138, RIRName: RIRRIPE})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"asn": 61138, "rir_name": "RIPE"}`, string(data))
+}
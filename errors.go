@@ -0,0 +1,79 @@
+package bgpview
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors usable with errors.Is on errors returned by the Client.
+var (
+	ErrNotFound    = errors.New("bgpview: not found")
+	ErrRateLimited = errors.New("bgpview: rate limited")
+	ErrServer      = errors.New("bgpview: server error")
+	// ErrQueryFailed matches successful HTTP responses whose BGPView status is not "ok" (e.g. malformed input).
+	ErrQueryFailed = errors.New("bgpview: query failed")
+)
+
+// APIError is returned when the BGPView API responds with an error.
+type APIError struct {
+	// HTTPStatus is the HTTP status code of the response.
+	HTTPStatus int
+	// Status is the `status` field of the BGPView response, if any.
+	Status string
+	// StatusMessage is the `status_message` field of the BGPView response, if any.
+	StatusMessage string
+	// Endpoint is the requested URL.
+	Endpoint string
+	// Body is the raw response body.
+	Body []byte
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+func newAPIError(endpoint string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		HTTPStatus: statusCode,
+		Endpoint:   endpoint,
+		Body:       body,
+	}
+
+	var envelope struct {
+		Status        string `json:"status"`
+		StatusMessage string `json:"status_message"`
+	}
+
+	if json.Unmarshal(body, &envelope) == nil {
+		apiErr.Status = envelope.Status
+		apiErr.StatusMessage = envelope.StatusMessage
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := e.StatusMessage
+	if msg == "" {
+		msg = string(e.Body)
+	}
+
+	return fmt.Sprintf("bgpview: %d: %s: %s", e.HTTPStatus, e.Endpoint, msg)
+}
+
+// Is reports whether the error matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPStatus == http.StatusNotFound
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests
+	case ErrServer:
+		return e.HTTPStatus >= http.StatusInternalServerError
+	case ErrQueryFailed:
+		return e.HTTPStatus < http.StatusBadRequest && e.Status != statusOK
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package bgpview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_APIError(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		statusCode int
+		body       string
+		target     error
+	}{
+		{
+			desc:       "not found",
+			statusCode: http.StatusNotFound,
+			body:       `{"status":"error","status_message":"Could not find ASN"}`,
+			target:     ErrNotFound,
+		},
+		{
+			desc:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			body:       `Too Many Requests`,
+			target:     ErrRateLimited,
+		},
+		{
+			desc:       "server error",
+			statusCode: http.StatusBadGateway,
+			body:       `Bad Gateway`,
+			target:     ErrServer,
+		},
+	}
+
+	calls := map[string]func(ctx context.Context, client *Client) error{
+		"GetASN":            func(ctx context.Context, c *Client) error { _, err := c.GetASN(ctx, 1); return err },
+		"GetASNPrefixes":    func(ctx context.Context, c *Client) error { _, err := c.GetASNPrefixes(ctx, 1); return err },
+		"GetASNPeers":       func(ctx context.Context, c *Client) error { _, err := c.GetASNPeers(ctx, 1); return err },
+		"GetASNUpstreams":   func(ctx context.Context, c *Client) error { _, err := c.GetASNUpstreams(ctx, 1); return err },
+		"GetASNDownstreams": func(ctx context.Context, c *Client) error { _, err := c.GetASNDownstreams(ctx, 1); return err },
+		"GetASNIxs":         func(ctx context.Context, c *Client) error { _, err := c.GetASNIxs(ctx, 1); return err },
+		"GetPrefix":         func(ctx context.Context, c *Client) error { _, err := c.GetPrefix(ctx, "192.0.2.0", 24); return err },
+		"GetIP":             func(ctx context.Context, c *Client) error { _, err := c.GetIP(ctx, "192.0.2.1"); return err },
+		"GetIX":             func(ctx context.Context, c *Client) error { _, err := c.GetIX(ctx, 1); return err },
+		"GetSearch":         func(ctx context.Context, c *Client) error { _, err := c.GetSearch(ctx, "test"); return err },
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			client, mux := setupTest(t)
+
+			mux.HandleFunc("/", func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(test.statusCode)
+				_, _ = rw.Write([]byte(test.body))
+			})
+
+			for name, call := range calls {
+				err := call(context.Background(), client)
+				require.Error(t, err, name)
+
+				assert.ErrorIs(t, err, test.target, name)
+
+				var apiErr *APIError
+				require.True(t, errors.As(err, &apiErr), name)
+				assert.Equal(t, test.statusCode, apiErr.HTTPStatus, name)
+				assert.Equal(t, test.body, string(apiErr.Body), name)
+				assert.NotEmpty(t, apiErr.Endpoint, name)
+			}
+		})
+	}
+}
+
+func TestAPIError_decodesEnvelope(t *testing.T) {
+	apiErr := newAPIError("https://api.bgpview.io/asn/0", http.StatusNotFound,
+		[]byte(`{"status":"error","status_message":"Could not find ASN"}`))
+
+	assert.Equal(t, "error", apiErr.Status)
+	assert.Equal(t, "Could not find ASN", apiErr.StatusMessage)
+	assert.EqualError(t, apiErr, "bgpview: 404: https://api.bgpview.io/asn/0: Could not find ASN")
+	assert.ErrorIs(t, apiErr, ErrNotFound)
+	assert.NotErrorIs(t, apiErr, ErrServer)
+}
+
+func TestClient_queryFailed(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		pattern  string
+		filename string
+		call     func(ctx context.Context, client *Client) error
+		message  string
+	}{
+		{
+			desc:     "malformed ASN",
+			pattern:  "/asn/0",
+			filename: "asn-malformed.json",
+			call:     func(ctx context.Context, c *Client) error { _, err := c.GetASN(ctx, 0); return err },
+			message:  "Malformed input",
+		},
+		{
+			desc:     "malformed prefix",
+			pattern:  "/prefix/192.0.2.1/33",
+			filename: "prefix-malformed.json",
+			call:     func(ctx context.Context, c *Client) error { _, err := c.GetPrefix(ctx, "192.0.2.1", 33); return err },
+			message:  "Malformed Prefix",
+		},
+		{
+			desc:     "malformed IP",
+			pattern:  "/ip/192.0.2",
+			filename: "ip-malformed.json",
+			call:     func(ctx context.Context, c *Client) error { _, err := c.GetIP(ctx, "192.0.2"); return err },
+			message:  "Malformed IP address",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			client, mux := setupTest(t)
+
+			mux.HandleFunc(test.pattern, testHandler(test.filename))
+
+			err := test.call(context.Background(), client)
+			require.Error(t, err)
+
+			assert.ErrorIs(t, err, ErrQueryFailed)
+			assert.NotErrorIs(t, err, ErrNotFound)
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+			assert.Equal(t, "error", apiErr.Status)
+			assert.Equal(t, test.message, apiErr.StatusMessage)
+		})
+	}
+}
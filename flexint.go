@@ -0,0 +1,45 @@
+package bgpview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlexInt is an integer decoded from a JSON number or a numeric string (e.g. 29 or "29").
+// Null and empty strings are decoded as 0.
+type FlexInt int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*i = 0
+		return nil
+	}
+
+	raw := string(data)
+
+	if strings.HasPrefix(raw, `"`) {
+		err := json.Unmarshal(data, &raw)
+		if err != nil {
+			return err
+		}
+
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			*i = 0
+			return nil
+		}
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+
+	*i = FlexInt(value)
+
+	return nil
+}
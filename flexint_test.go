@@ -0,0 +1,64 @@
+package bgpview
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexInt_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected FlexInt
+	}{
+		{desc: "number", data: `29`, expected: 29},
+		{desc: "string", data: `"29"`, expected: 29},
+		{desc: "string with spaces", data: `" 48 "`, expected: 48},
+		{desc: "empty string", data: `""`},
+		{desc: "null", data: `null`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var value FlexInt
+
+			err := json.Unmarshal([]byte(test.data), &value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestFlexInt_UnmarshalJSON_invalid(t *testing.T) {
+	for _, data := range []string{`"x"`, `2.5`, `true`} {
+		var value FlexInt
+
+		err := json.Unmarshal([]byte(data), &value)
+		assert.Error(t, err, data)
+	}
+}
+
+func TestAllocationData_shared(t *testing.T) {
+	// The /ip endpoint returns the CIDR as a string, the /prefix and /asn endpoints as a number.
+	var fromIP, fromPrefix AllocationData
+
+	err := json.Unmarshal([]byte(`{"rir_name": "RIPE", "ip": "2a05:dfc0::", "cidr": "29", "prefix": "2a05:dfc0::/29"}`), &fromIP)
+	require.NoError(t, err)
+
+	err = json.Unmarshal([]byte(`{"rir_name": "RIPE", "ip": "2a05:dfc0::", "cidr": 29, "prefix": "2a05:dfc0::/29"}`), &fromPrefix)
+	require.NoError(t, err)
+
+	assert.Equal(t, fromPrefix, fromIP)
+
+	data, err := json.Marshal(fromIP)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"rir_name": "RIPE", "ip": "2a05:dfc0::", "cidr": 29, "prefix": "2a05:dfc0::/29", "date_allocated": null}`, string(data))
+}
@@ -0,0 +1,94 @@
+package bgpview
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup coalesces concurrent identical requests into a single round trip.
+// The shared round trip is canceled only when all its callers are gone.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go g.run(callCtx, key, call, fn)
+	}
+
+	call.waiters++
+
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+
+	case <-ctx.Done():
+		g.mu.Lock()
+
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			g.forget(key, call)
+		}
+
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) ([]byte, error)) {
+	call.body, call.err = fn(ctx)
+	call.cancel()
+
+	g.mu.Lock()
+	g.forget(key, call)
+	g.mu.Unlock()
+
+	close(call.done)
+}
+
+// forget removes the call from the group, if it's still the current call for the key.
+// The caller must hold the lock.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// detachedContext keeps the values of its parent but not its deadline and cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
@@ -0,0 +1,153 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waiters(g *flightGroup, key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	call, ok := g.calls[key]
+	if !ok {
+		return 0
+	}
+
+	return call.waiters
+}
+
+func TestClient_coalescing(t *testing.T) {
+	client, mux := setupTest(t)
+
+	var calls int32
+
+	release := make(chan struct{})
+
+	mux.HandleFunc("/asn/61138", countingHandler(&calls, func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		testHandler("asn.json")(rw, req)
+	}))
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+
+	results := make([]*ASNInfo, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			details, err := client.GetASN(context.Background(), 61138)
+			assert.NoError(t, err)
+
+			results[i] = details
+		}(i)
+	}
+
+	key := client.baseURL.JoinPath("asn", "61138").String()
+
+	require.Eventually(t, func() bool { return waiters(client.flights, key) == callers }, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	for _, details := range results {
+		require.NotNil(t, details)
+		assert.Equal(t, "ZAPPIE-HOST-AS", details.Data.Name)
+	}
+
+	// Each caller gets its own copy of the result.
+	results[0].Data.Name = "modified"
+	assert.Equal(t, "ZAPPIE-HOST-AS", results[1].Data.Name)
+}
+
+func TestClient_coalescing_callerCanceled(t *testing.T) {
+	client, mux := setupTest(t)
+
+	var calls int32
+
+	release := make(chan struct{})
+
+	mux.HandleFunc("/asn/61138", countingHandler(&calls, func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		testHandler("asn.json")(rw, req)
+	}))
+
+	key := client.baseURL.JoinPath("asn", "61138").String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	canceled := make(chan error)
+
+	go func() {
+		_, err := client.GetASN(ctx, 61138)
+		canceled <- err
+	}()
+
+	done := make(chan error)
+
+	go func() {
+		_, err := client.GetASN(context.Background(), 61138)
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool { return waiters(client.flights, key) == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-canceled, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-done)
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestFlightGroup_allCallersCanceled(t *testing.T) {
+	group := newFlightGroup()
+
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := group.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+
+		return nil, ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case err = <-stopped:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the shared call must be canceled when all the callers are gone")
+	}
+
+	body, err := group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		return []byte("ok"), nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", string(body))
+}
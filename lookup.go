@@ -0,0 +1,230 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// QueryKind is the kind of a lookup query.
+type QueryKind string
+
+// Query kinds.
+const (
+	QueryASN    QueryKind = "asn"
+	QueryIP     QueryKind = "ip"
+	QueryPrefix QueryKind = "prefix"
+	QueryIX     QueryKind = "ix"
+	QuerySearch QueryKind = "search"
+)
+
+// Query is a classified lookup term.
+type Query struct {
+	Kind QueryKind `json:"kind"`
+	// Term is the term as given, trimmed.
+	Term string `json:"term"`
+
+	// ASN is set for QueryASN.
+	ASN int `json:"asn,omitempty"`
+	// IP is set for QueryIP.
+	IP netip.Addr `json:"ip,omitempty"`
+	// Prefix is set for QueryPrefix, masked (e.g. "192.0.2.1/24" is 192.0.2.0/24).
+	Prefix netip.Prefix `json:"prefix,omitempty"`
+	// IXID is set for QueryIX.
+	IXID int `json:"ix_id,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, omitting the zero IP and prefix.
+func (q Query) MarshalJSON() ([]byte, error) {
+	type plain Query
+
+	var (
+		ip     *netip.Addr
+		prefix *netip.Prefix
+	)
+
+	if q.IP.IsValid() {
+		ip = &q.IP
+	}
+
+	if q.Prefix.IsValid() {
+		prefix = &q.Prefix
+	}
+
+	return json.Marshal(struct {
+		plain
+		IP     *netip.Addr   `json:"ip,omitempty"`
+		Prefix *netip.Prefix `json:"prefix,omitempty"`
+	}{plain: plain(q), IP: ip, Prefix: prefix})
+}
+
+// ParseQuery classifies a free-form lookup term:
+//   - an ASN in asplain ("61138", "AS61138") or asdot ("AS0.61138", "1.10") notation;
+//   - an IP address ("45.67.13.7", "2a05:dfc7:60::");
+//   - a prefix ("2a06:1280::/32");
+//   - an IX ID prefixed by "IX" ("IX492", "ix 492", "ix:492");
+//   - anything else is a search term.
+func ParseQuery(term string) (Query, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return Query{}, errors.New("empty query")
+	}
+
+	query := Query{Term: term}
+
+	if asNumber, ok := parseASNotation(term); ok {
+		query.Kind = QueryASN
+		query.ASN = asNumber
+
+		return query, nil
+	}
+
+	if ip, err := netip.ParseAddr(term); err == nil {
+		query.Kind = QueryIP
+		query.IP = ip.Unmap().WithZone("")
+
+		return query, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(term); err == nil {
+		query.Kind = QueryPrefix
+		query.Prefix = prefix.Masked()
+
+		return query, nil
+	}
+
+	if ixID, ok := parseIXID(term); ok {
+		query.Kind = QueryIX
+		query.IXID = ixID
+
+		return query, nil
+	}
+
+	query.Kind = QuerySearch
+
+	return query, nil
+}
+
+// parseASNotation parses an ASN in asplain or asdot notation, optionally prefixed by "AS".
+func parseASNotation(term string) (int, bool) {
+	if len(term) > 2 && strings.EqualFold(term[:2], "AS") {
+		term = strings.TrimSpace(term[2:])
+	}
+
+	var value uint64
+
+	high, low, dotted := strings.Cut(term, ".")
+	if dotted {
+		highValue, err := parseUint(high, 16)
+		if err != nil {
+			return 0, false
+		}
+
+		lowValue, err := parseUint(low, 16)
+		if err != nil {
+			return 0, false
+		}
+
+		value = highValue<<16 | lowValue
+	} else {
+		plain, err := parseUint(term, 32)
+		if err != nil {
+			return 0, false
+		}
+
+		value = plain
+	}
+
+	if value == 0 {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+// parseUint parses digits only (no sign).
+func parseUint(s string, bitSize int) (uint64, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, strconv.ErrSyntax
+	}
+
+	return strconv.ParseUint(s, 10, bitSize)
+}
+
+// parseIXID parses an IX ID prefixed by "IX", optionally separated by a space, ":", "-" or "#".
+func parseIXID(term string) (int, bool) {
+	if len(term) < 3 || !strings.EqualFold(term[:2], "IX") {
+		return 0, false
+	}
+
+	id := strings.TrimLeft(term[2:], " :-#")
+
+	value, err := parseUint(id, 31)
+	if err != nil || value == 0 {
+		return 0, false
+	}
+
+	return int(value), true
+}
+
+// LookupResult is the result of Lookup: a tagged union of the responses, Query.Kind telling which one is set.
+type LookupResult struct {
+	Query Query `json:"query"`
+
+	ASN    *ASNInfo    `json:"asn,omitempty"`
+	IP     *IPInfo     `json:"ip,omitempty"`
+	Prefix *PrefixInfo `json:"prefix,omitempty"`
+	IX     *IXInfo     `json:"ix,omitempty"`
+	Search *SearchInfo `json:"search,omitempty"`
+}
+
+// Response returns the response of the lookup (e.g. *ASNInfo for QueryASN), or nil if there is none.
+func (r *LookupResult) Response() interface{} {
+	switch {
+	case r.ASN != nil:
+		return r.ASN
+	case r.IP != nil:
+		return r.IP
+	case r.Prefix != nil:
+		return r.Prefix
+	case r.IX != nil:
+		return r.IX
+	case r.Search != nil:
+		return r.Search
+	default:
+		return nil
+	}
+}
+
+// Lookup classifies a free-form term (see ParseQuery), and gets the matching ASN, IP, prefix or IX, or searches the term.
+func (c Client) Lookup(ctx context.Context, term string) (*LookupResult, error) {
+	query, err := ParseQuery(term)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LookupResult{Query: query}
+
+	switch query.Kind {
+	case QueryASN:
+		result.ASN, err = c.GetASN(ctx, query.ASN)
+	case QueryIP:
+		result.IP, err = c.GetNetIP(ctx, query.IP)
+	case QueryPrefix:
+		result.Prefix, err = c.GetNetPrefix(ctx, query.Prefix)
+	case QueryIX:
+		result.IX, err = c.GetIX(ctx, query.IXID)
+	default:
+		result.Search, err = c.GetSearch(ctx, query.Term)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("lookup of %s %q: %w", query.Kind, query.Term, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	testCases := []struct {
+		term     string
+		expected Query
+	}{
+		{term: "AS61138", expected: Query{Kind: QueryASN, Term: "AS61138", ASN: 61138}},
+		{term: " as61138 ", expected: Query{Kind: QueryASN, Term: "as61138", ASN: 61138}},
+		{term: "AS 61138", expected: Query{Kind: QueryASN, Term: "AS 61138", ASN: 61138}},
+		{term: "61138", expected: Query{Kind: QueryASN, Term: "61138", ASN: 61138}},
+		{term: "4294967295", expected: Query{Kind: QueryASN, Term: "4294967295", ASN: 4294967295}},
+		{term: "AS1.10", expected: Query{Kind: QueryASN, Term: "AS1.10", ASN: 65546}},
+		{term: "0.61138", expected: Query{Kind: QueryASN, Term: "0.61138", ASN: 61138}},
+		{term: "45.67.13.7", expected: Query{Kind: QueryIP, Term: "45.67.13.7", IP: netip.MustParseAddr("45.67.13.7")}},
+		{term: "2a05:dfc7:60::", expected: Query{Kind: QueryIP, Term: "2a05:dfc7:60::", IP: netip.MustParseAddr("2a05:dfc7:60::")}},
+		{term: "::ffff:45.67.13.7", expected: Query{Kind: QueryIP, Term: "::ffff:45.67.13.7", IP: netip.MustParseAddr("45.67.13.7")}},
+		{term: "2a06:1280::/32", expected: Query{Kind: QueryPrefix, Term: "2a06:1280::/32", Prefix: netip.MustParsePrefix("2a06:1280::/32")}},
+		{term: "192.209.63.7/24", expected: Query{Kind: QueryPrefix, Term: "192.209.63.7/24", Prefix: netip.MustParsePrefix("192.209.63.0/24")}},
+		{term: "IX492", expected: Query{Kind: QueryIX, Term: "IX492", IXID: 492}},
+		{term: "ix 492", expected: Query{Kind: QueryIX, Term: "ix 492", IXID: 492}},
+		{term: "ix:492", expected: Query{Kind: QueryIX, Term: "ix:492", IXID: 492}},
+		{term: "digitalocean", expected: Query{Kind: QuerySearch, Term: "digitalocean"}},
+		{term: "AS0", expected: Query{Kind: QuerySearch, Term: "AS0"}},
+		{term: "4294967296", expected: Query{Kind: QuerySearch, Term: "4294967296"}},
+		{term: "1.65536", expected: Query{Kind: QuerySearch, Term: "1.65536"}},
+		{term: "+61138", expected: Query{Kind: QuerySearch, Term: "+61138"}},
+		{term: "IXP", expected: Query{Kind: QuerySearch, Term: "IXP"}},
+		{term: "ASN61138", expected: Query{Kind: QuerySearch, Term: "ASN61138"}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.term, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := ParseQuery(test.term)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, query)
+		})
+	}
+}
+
+func TestParseQuery_empty(t *testing.T) {
+	_, err := ParseQuery("  ")
+	require.Error(t, err)
+}
+
+func TestQuery_MarshalJSON(t *testing.T) {
+	testCases := map[string]string{
+		"digitalocean":    `{"kind": "search", "term": "digitalocean"}`,
+		"AS61138":         `{"kind": "asn", "term": "AS61138", "asn": 61138}`,
+		"45.67.13.7":      `{"kind": "ip", "term": "45.67.13.7", "ip": "45.67.13.7"}`,
+		"192.209.63.7/24": `{"kind": "prefix", "term": "192.209.63.7/24", "prefix": "192.209.63.0/24"}`,
+		"IX492":           `{"kind": "ix", "term": "IX492", "ix_id": 492}`,
+	}
+
+	for term, expected := range testCases {
+		query, err := ParseQuery(term)
+		require.NoError(t, err, term)
+
+		data, err := json.Marshal(query)
+		require.NoError(t, err, term)
+
+		assert.JSONEq(t, expected, string(data), term)
+
+		var decoded Query
+
+		err = json.Unmarshal(data, &decoded)
+		require.NoError(t, err, term)
+
+		assert.Equal(t, query, decoded, term)
+	}
+}
+
+func TestClient_Lookup(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138", testHandler("asn.json"))
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip.json"))
+	mux.HandleFunc("/prefix/192.209.63.0/24", testHandler("prefix.json"))
+	mux.HandleFunc("/ix/492", testHandler("ix.json"))
+	mux.HandleFunc("/search", testHandler("search.json"))
+
+	ctx := context.Background()
+
+	result, err := client.Lookup(ctx, "AS61138")
+	require.NoError(t, err)
+	assert.Equal(t, QueryASN, result.Query.Kind)
+	require.NotNil(t, result.ASN)
+	assert.Equal(t, "ZAPPIE-HOST-AS", result.ASN.Data.Name)
+	assert.Same(t, result.ASN, result.Response())
+
+	result, err = client.Lookup(ctx, "2a05:dfc7:60::")
+	require.NoError(t, err)
+	require.NotNil(t, result.IP)
+	assert.Same(t, result.IP, result.Response())
+
+	result, err = client.Lookup(ctx, "192.209.63.9/24")
+	require.NoError(t, err)
+	require.NotNil(t, result.Prefix)
+	assert.Equal(t, "BITACCEL-NETWORK", result.Prefix.Data.Name)
+
+	result, err = client.Lookup(ctx, "IX492")
+	require.NoError(t, err)
+	require.NotNil(t, result.IX)
+	assert.Same(t, result.IX, result.Response())
+
+	result, err = client.Lookup(ctx, "digitalocean")
+	require.NoError(t, err)
+	require.NotNil(t, result.Search)
+	assert.Nil(t, result.ASN)
+	assert.Same(t, result.Search, result.Response())
+}
+
+func TestClient_Lookup_errors(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/1", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.Lookup(context.Background(), "AS1")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), `lookup of asn "AS1"`)
+
+	_, err = client.Lookup(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestLookupResult_Response(t *testing.T) {
+	assert.Nil(t, (&LookupResult{}).Response())
+}
@@ -0,0 +1,237 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Doer performs HTTP requests. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use a function as a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer performing the HTTP requests (each retry is a new request).
+type Middleware func(next Doer) Doer
+
+// RequestInfo describes a completed HTTP request.
+type RequestInfo struct {
+	// Endpoint is the name of the endpoint (e.g. "asn", "asn/prefixes", "search").
+	Endpoint string
+	// URL is the requested URL.
+	URL string
+	// Duration is the time until the response headers are received.
+	Duration time.Duration
+	// StatusCode is the HTTP status code, 0 if the request failed.
+	StatusCode int
+	// Err is the error of the request: the transport error,
+	// or the *APIError of an error response (HTTP status or "error" status of the payload).
+	Err error
+}
+
+// Hook creates a Middleware calling fn after each HTTP request.
+// Within a Client, fn is called once the response is read, so that RequestInfo.Err is the final error of the request.
+func Hook(fn func(ctx context.Context, info RequestInfo)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+
+			resp, err := next.Do(req)
+
+			info := RequestInfo{
+				Endpoint: EndpointName(req.Context()),
+				URL:      req.URL.String(),
+				Duration: time.Since(start),
+				Err:      err,
+			}
+
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+			}
+
+			hooks, ok := req.Context().Value(requestHooksKey{}).(*requestHooks)
+			if !ok || err != nil {
+				fn(req.Context(), info)
+				return resp, err
+			}
+
+			hooks.add(func(err error) {
+				info.Err = err
+				fn(req.Context(), info)
+			})
+
+			return resp, err
+		})
+	}
+}
+
+type requestHooksKey struct{}
+
+// requestHooks are the hooks waiting for the final error of a request.
+type requestHooks struct {
+	mu    sync.Mutex
+	hooks []func(err error)
+}
+
+func withRequestHooks(ctx context.Context) (context.Context, *requestHooks) {
+	hooks := &requestHooks{}
+	return context.WithValue(ctx, requestHooksKey{}, hooks), hooks
+}
+
+func (h *requestHooks) add(hook func(err error)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, hook)
+}
+
+// run calls the hooks with the final error of the request.
+func (h *requestHooks) run(err error) {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(err)
+	}
+}
+
+type endpointNameKey struct{}
+
+// EndpointName returns the name of the endpoint (e.g. "asn/prefixes") of a request context.
+func EndpointName(ctx context.Context) string {
+	name, _ := ctx.Value(endpointNameKey{}).(string)
+	return name
+}
+
+func withEndpointName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, endpointNameKey{}, name)
+}
+
+// endpointName returns the name of an endpoint: the family, followed by the sub-resource of an ASN (e.g. "asn/prefixes").
+func endpointName(baseURL, endpoint *url.URL) string {
+	segments := endpointSegments(baseURL, endpoint)
+	if segments[0] == FamilyASN && len(segments) > 2 {
+		return FamilyASN + "/" + segments[2]
+	}
+
+	return segments[0]
+}
+
+func (c Client) doer() Doer {
+	var doer Doer = c.HTTPClient
+
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		doer = c.Middlewares[i](doer)
+	}
+
+	return doer
+}
+
+// LatencyHistogram records the latency of the requests by endpoint.
+// It is safe for concurrent use.
+type LatencyHistogram struct {
+	mu        sync.Mutex
+	buckets   []time.Duration
+	endpoints map[string]*HistogramSnapshot
+}
+
+// HistogramSnapshot is the state of the histogram of an endpoint.
+type HistogramSnapshot struct {
+	// Buckets are the upper bounds of the buckets.
+	Buckets []time.Duration
+	// Counts are the number of requests by bucket (not cumulative),
+	// the last count is the number of requests above the last bucket.
+	Counts []uint64
+	// Count is the total number of requests.
+	Count uint64
+	// Sum is the total latency.
+	Sum time.Duration
+}
+
+// NewLatencyHistogram creates a new LatencyHistogram.
+// Without buckets, default buckets from 50ms to 10s are used.
+func NewLatencyHistogram(buckets ...time.Duration) *LatencyHistogram {
+	if len(buckets) == 0 {
+		buckets = []time.Duration{
+			50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+			time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+		}
+	}
+
+	sorted := append([]time.Duration(nil), buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &LatencyHistogram{
+		buckets:   sorted,
+		endpoints: make(map[string]*HistogramSnapshot),
+	}
+}
+
+// Middleware creates a Middleware recording the latency of the requests.
+func (h *LatencyHistogram) Middleware() Middleware {
+	return Hook(func(_ context.Context, info RequestInfo) {
+		h.Observe(info.Endpoint, info.Duration)
+	})
+}
+
+// Observe records a latency.
+func (h *LatencyHistogram) Observe(endpoint string, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot, ok := h.endpoints[endpoint]
+	if !ok {
+		snapshot = &HistogramSnapshot{Buckets: h.buckets, Counts: make([]uint64, len(h.buckets)+1)}
+		h.endpoints[endpoint] = snapshot
+	}
+
+	index := sort.Search(len(h.buckets), func(i int) bool { return latency <= h.buckets[i] })
+
+	snapshot.Counts[index]++
+	snapshot.Count++
+	snapshot.Sum += latency
+}
+
+// Endpoints returns the sorted names of the observed endpoints.
+func (h *LatencyHistogram) Endpoints() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	names := make([]string, 0, len(h.endpoints))
+	for name := range h.endpoints {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Snapshot returns a copy of the histogram of an endpoint.
+func (h *LatencyHistogram) Snapshot(endpoint string) HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot, ok := h.endpoints[endpoint]
+	if !ok {
+		return HistogramSnapshot{Buckets: h.buckets, Counts: make([]uint64, len(h.buckets)+1)}
+	}
+
+	result := *snapshot
+	result.Counts = append([]uint64(nil), snapshot.Counts...)
+
+	return result
+}
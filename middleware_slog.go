@@ -0,0 +1,33 @@
+//go:build go1.21
+
+package bgpview
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// LoggingMiddleware creates a Middleware logging each request with log/slog.
+// Successful requests are logged at the debug level, failures at the warning level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return Hook(func(ctx context.Context, info RequestInfo) {
+		level := slog.LevelDebug
+		if info.Err != nil || info.StatusCode != http.StatusOK {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("endpoint", info.Endpoint),
+			slog.String("url", info.URL),
+			slog.Duration("duration", info.Duration),
+			slog.Int("status", info.StatusCode),
+		}
+
+		if info.Err != nil {
+			attrs = append(attrs, slog.String("error", info.Err.Error()))
+		}
+
+		logger.LogAttrs(ctx, level, "bgpview request", attrs...)
+	})
+}
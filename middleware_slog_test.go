@@ -0,0 +1,46 @@
+//go:build go1.21
+
+package bgpview
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138", testHandler("asn.json"))
+	mux.HandleFunc("/asn/2", testHandler("asn-malformed.json"))
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client.Middlewares = []Middleware{LoggingMiddleware(logger)}
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	_, err = client.GetASN(context.Background(), 1)
+	require.Error(t, err)
+
+	_, err = client.GetASN(context.Background(), 2)
+	require.Error(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	assert.Contains(t, string(lines[0]), `level=DEBUG msg="bgpview request" endpoint=asn`)
+	assert.Contains(t, string(lines[0]), "status=200")
+	assert.Contains(t, string(lines[1]), `level=WARN msg="bgpview request" endpoint=asn`)
+	assert.Contains(t, string(lines[1]), "status=404")
+	assert.Contains(t, string(lines[1]), "error=")
+	assert.Contains(t, string(lines[2]), `level=WARN msg="bgpview request" endpoint=asn`)
+	assert.Contains(t, string(lines[2]), "status=200")
+	assert.Contains(t, string(lines[2]), "Malformed input")
+}
@@ -0,0 +1,161 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_middlewares(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/61138/prefixes", testHandler("asn-prefixes.json"))
+	mux.HandleFunc("/asn/2", testHandler("asn-malformed.json"))
+
+	var (
+		mu    sync.Mutex
+		order []string
+		infos []RequestInfo
+	)
+
+	tracer := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+
+				req.Header.Set("X-Trace-"+name, "1")
+
+				return next.Do(req)
+			})
+		}
+	}
+
+	client.Middlewares = []Middleware{
+		tracer("outer"),
+		tracer("inner"),
+		Hook(func(_ context.Context, info RequestInfo) {
+			mu.Lock()
+			infos = append(infos, info)
+			mu.Unlock()
+		}),
+	}
+
+	_, err := client.GetASNPrefixes(context.Background(), 61138)
+	require.NoError(t, err)
+
+	_, err = client.GetASN(context.Background(), 1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// An "error" status with a 200 HTTP status.
+	_, err = client.GetASN(context.Background(), 2)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"outer", "inner", "outer", "inner", "outer", "inner"}, order)
+
+	require.Len(t, infos, 3)
+
+	assert.Equal(t, "asn/prefixes", infos[0].Endpoint)
+	assert.Equal(t, client.baseURL.String()+"/asn/61138/prefixes", infos[0].URL)
+	assert.Equal(t, http.StatusOK, infos[0].StatusCode)
+	assert.Positive(t, infos[0].Duration)
+	assert.NoError(t, infos[0].Err)
+
+	assert.Equal(t, "asn", infos[1].Endpoint)
+	assert.Equal(t, http.StatusNotFound, infos[1].StatusCode)
+	assert.ErrorIs(t, infos[1].Err, ErrNotFound)
+
+	var apiErr *APIError
+	assert.Equal(t, http.StatusOK, infos[2].StatusCode)
+	require.ErrorAs(t, infos[2].Err, &apiErr)
+	assert.Equal(t, "Malformed input", apiErr.StatusMessage)
+}
+
+func TestHook_withoutClient(t *testing.T) {
+	var infos []RequestInfo
+
+	doer := Hook(func(_ context.Context, info RequestInfo) {
+		infos = append(infos, info)
+	})(DoerFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTeapot, Body: http.NoBody}, nil
+	}))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com/asn/1", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := doer.Do(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	require.Len(t, infos, 1)
+	assert.Equal(t, http.StatusTeapot, infos[0].StatusCode)
+	assert.NoError(t, infos[0].Err)
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ix/492", testHandler("ix.json"))
+
+	histogram := NewLatencyHistogram(time.Millisecond, time.Minute)
+	client.Middlewares = []Middleware{histogram.Middleware()}
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetIX(context.Background(), 492)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"ix"}, histogram.Endpoints())
+
+	snapshot := histogram.Snapshot("ix")
+	assert.EqualValues(t, 3, snapshot.Count)
+	assert.Positive(t, snapshot.Sum)
+	assert.Equal(t, []time.Duration{time.Millisecond, time.Minute}, snapshot.Buckets)
+	assert.EqualValues(t, 3, snapshot.Counts[0]+snapshot.Counts[1])
+}
+
+func TestLatencyHistogram_Observe(t *testing.T) {
+	histogram := NewLatencyHistogram(time.Second, 100*time.Millisecond)
+
+	histogram.Observe("asn", 50*time.Millisecond)
+	histogram.Observe("asn", 100*time.Millisecond)
+	histogram.Observe("asn", 500*time.Millisecond)
+	histogram.Observe("asn", time.Hour)
+
+	snapshot := histogram.Snapshot("asn")
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, time.Second}, snapshot.Buckets)
+	assert.Equal(t, []uint64{2, 1, 1}, snapshot.Counts)
+	assert.EqualValues(t, 4, snapshot.Count)
+
+	assert.Equal(t, []uint64{0, 0, 0}, histogram.Snapshot("search").Counts)
+}
+
+func Test_endpointName(t *testing.T) {
+	baseURL, err := url.Parse("https://api.bgpview.io")
+	require.NoError(t, err)
+
+	testCases := map[string]string{
+		"/asn/61138":                 "asn",
+		"/asn/61138/prefixes":        "asn/prefixes",
+		"/asn/61138/ixs":             "asn/ixs",
+		"/prefix/192.209.63.0/24":    "prefix",
+		"/ip/2a05:dfc7:60::":         "ip",
+		"/ix/492":                    "ix",
+		"/search?query_term=example": "search",
+	}
+
+	for endpoint, expected := range testCases {
+		u, err := baseURL.Parse(endpoint)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, endpointName(baseURL, u), endpoint)
+	}
+}
@@ -0,0 +1,126 @@
+package bgpview
+
+import "net/netip"
+
+// NetPrefix returns the prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d ASNIPPrefixesData) NetPrefix() netip.Prefix {
+	return parsePrefix(d.Prefix, d.IP, d.CIDR)
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d ASNIPPrefixesData) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetPrefix returns the prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d ASNPrefixesParent) NetPrefix() netip.Prefix {
+	return parsePrefix(d.Prefix, d.IP, d.CIDR)
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d ASNPrefixesParent) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetPrefix returns the prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d PrefixData) NetPrefix() netip.Prefix {
+	return parsePrefix(d.Prefix, d.IP, d.CIDR)
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d PrefixData) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetPrefix returns the prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d AllocationData) NetPrefix() netip.Prefix {
+	return parsePrefix(d.Prefix, d.IP, int(d.CIDR))
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d AllocationData) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d IPData) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetPrefix returns the prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d SearchIPPrefixesData) NetPrefix() netip.Prefix {
+	return parsePrefix(d.Prefix, d.IP, d.CIDR)
+}
+
+// NetIP returns the IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d SearchIPPrefixesData) NetIP() netip.Addr {
+	return parseAddr(d.IP)
+}
+
+// NetParentPrefix returns the parent prefix as a netip.Prefix, the zero value if it's empty or malformed.
+func (d SearchIPPrefixesData) NetParentPrefix() netip.Prefix {
+	return parsePrefix(d.ParentPrefix, d.ParentIP, d.ParentCIDR)
+}
+
+// NetParentIP returns the parent IP as a netip.Addr, the zero value if it's empty or malformed.
+func (d SearchIPPrefixesData) NetParentIP() netip.Addr {
+	return parseAddr(d.ParentIP)
+}
+
+// NetIPv4Address returns the IPv4 address as a netip.Addr, the zero value if it's empty or malformed.
+func (d ASNIxsData) NetIPv4Address() netip.Addr {
+	return parseAddr(d.IPv4Address)
+}
+
+// NetIPv6Address returns the IPv6 address as a netip.Addr, the zero value if it's empty or malformed.
+func (d ASNIxsData) NetIPv6Address() netip.Addr {
+	return parseAddr(d.IPv6Address)
+}
+
+// NetIPv4Address returns the IPv4 address as a netip.Addr, the zero value if it's empty or malformed.
+func (d MemberData) NetIPv4Address() netip.Addr {
+	return parseAddr(d.IPv4Address)
+}
+
+// NetIPv6Address returns the IPv6 address as a netip.Addr, the zero value if it's empty or malformed.
+func (d MemberData) NetIPv6Address() netip.Addr {
+	return parseAddr(d.IPv6Address)
+}
+
+// parsePrefix parses a prefix (e.g. "192.0.2.0/24"),
+// or builds it from the IP and the CIDR when the prefix is empty.
+func parsePrefix(prefix, ip string, cidr int) netip.Prefix {
+	if prefix != "" {
+		p, err := netip.ParsePrefix(prefix)
+		if err != nil {
+			return netip.Prefix{}
+		}
+
+		return p
+	}
+
+	addr := parseAddr(ip)
+	if !addr.IsValid() {
+		return netip.Prefix{}
+	}
+
+	p := netip.PrefixFrom(addr, cidr)
+	if !p.IsValid() {
+		return netip.Prefix{}
+	}
+
+	return p
+}
+
+func parseAddr(ip string) netip.Addr {
+	if ip == "" {
+		return netip.Addr{}
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return addr
+}
@@ -0,0 +1,124 @@
+package bgpview
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetNetPrefix(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/prefix/192.209.63.0/24", testHandler("prefix.json"))
+
+	prefix, err := client.GetNetPrefix(context.Background(), netip.MustParsePrefix("192.209.63.0/24"))
+	require.NoError(t, err)
+
+	assert.Equal(t, netip.MustParsePrefix("192.209.63.0/24"), prefix.Data.NetPrefix())
+	assert.Equal(t, netip.MustParseAddr("192.209.63.0"), prefix.Data.NetIP())
+	assert.Equal(t, netip.MustParsePrefix("192.209.62.0/23"), prefix.Data.RIRAllocation.NetPrefix())
+}
+
+func TestClient_GetNetPrefix_unmasked(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/prefix/192.209.63.0/24", testHandler("prefix.json"))
+
+	prefix, err := client.GetNetPrefix(context.Background(), netip.MustParsePrefix("192.209.63.7/24"))
+	require.NoError(t, err)
+
+	assert.Equal(t, netip.MustParsePrefix("192.209.63.0/24"), prefix.Data.NetPrefix())
+}
+
+func TestClient_GetNetPrefix_invalid(t *testing.T) {
+	client, _ := setupTest(t)
+
+	_, err := client.GetNetPrefix(context.Background(), netip.Prefix{})
+	require.EqualError(t, err, "invalid prefix: invalid Prefix")
+}
+
+func TestClient_GetNetIP(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip.json"))
+
+	ip, err := client.GetNetIP(context.Background(), netip.MustParseAddr("2a05:dfc7:60::"))
+	require.NoError(t, err)
+
+	assert.Equal(t, netip.MustParseAddr("2a05:dfc7:60::"), ip.Data.NetIP())
+	assert.Equal(t, netip.MustParsePrefix("2a05:dfc0::/29"), ip.Data.RIRAllocation.NetPrefix())
+}
+
+func TestClient_GetNetIP_invalid(t *testing.T) {
+	client, _ := setupTest(t)
+
+	_, err := client.GetNetIP(context.Background(), netip.Addr{})
+	require.EqualError(t, err, "invalid IP address: invalid IP")
+}
+
+func TestASNIPPrefixesData_NetPrefix(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     ASNIPPrefixesData
+		expected netip.Prefix
+	}{
+		{
+			desc:     "prefix",
+			data:     ASNIPPrefixesData{Prefix: "192.0.2.0/24", IP: "192.0.2.0", CIDR: 24},
+			expected: netip.MustParsePrefix("192.0.2.0/24"),
+		},
+		{
+			desc:     "IP and CIDR only",
+			data:     ASNIPPrefixesData{IP: "2001:db8::", CIDR: 32},
+			expected: netip.MustParsePrefix("2001:db8::/32"),
+		},
+		{
+			desc: "empty",
+			data: ASNIPPrefixesData{},
+		},
+		{
+			desc: "malformed prefix",
+			data: ASNIPPrefixesData{Prefix: "192.0.2.0/33"},
+		},
+		{
+			desc: "CIDR out of range",
+			data: ASNIPPrefixesData{IP: "192.0.2.0", CIDR: 64},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.data.NetPrefix())
+		})
+	}
+}
+
+func TestSearchIPPrefixesData_NetParentPrefix(t *testing.T) {
+	data := SearchIPPrefixesData{ParentPrefix: "2a03:b0c0::/32", ParentIP: "2a03:b0c0::", ParentCIDR: 32}
+
+	assert.Equal(t, netip.MustParsePrefix("2a03:b0c0::/32"), data.NetParentPrefix())
+	assert.Equal(t, netip.MustParseAddr("2a03:b0c0::"), data.NetParentIP())
+}
+
+func TestMemberData_NetIPv4Address(t *testing.T) {
+	member := MemberData{IPv4Address: "80.249.208.1", IPv6Address: ""}
+
+	assert.Equal(t, netip.MustParseAddr("80.249.208.1"), member.NetIPv4Address())
+	assert.False(t, member.NetIPv6Address().IsValid())
+
+	ix := ASNIxsData{IPv4Address: "not an IP", IPv6Address: "2001:7f8:1::a506:1138:1"}
+
+	assert.False(t, ix.NetIPv4Address().IsValid())
+	assert.Equal(t, netip.MustParseAddr("2001:7f8:1::a506:1138:1"), ix.NetIPv6Address())
+}
+
+func TestAllocationData_NetPrefix(t *testing.T) {
+	assert.Equal(t, netip.MustParsePrefix("2a05:dfc0::/29"), AllocationData{IP: "2a05:dfc0::", CIDR: 29}.NetPrefix())
+	assert.False(t, AllocationData{IP: "2a05:dfc0::", CIDR: 200}.NetPrefix().IsValid())
+}
@@ -0,0 +1,146 @@
+package bgpview
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client.
+type Option func(c *Client) error
+
+// WithBaseURL sets the base URL of the API (e.g. a self-hosted mirror).
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		baseURL, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+
+		if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+			return fmt.Errorf("invalid base URL %q: unsupported scheme %q", rawURL, baseURL.Scheme)
+		}
+
+		if baseURL.Host == "" {
+			return fmt.Errorf("invalid base URL %q: missing host", rawURL)
+		}
+
+		c.baseURL = baseURL
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to perform the requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) error {
+		if client == nil {
+			return errors.New("HTTP client must not be nil")
+		}
+
+		c.HTTPClient = client
+
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client, whatever the order of the options (e.g. with WithHTTPClient).
+// The HTTP client is copied, so a shared HTTP client is not modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("invalid timeout: %s", timeout)
+		}
+
+		c.timeout = &timeout
+
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader adds a header sent with each request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) error {
+		if key == "" {
+			return errors.New("header key must not be empty")
+		}
+
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+
+		c.headers.Set(key, value)
+
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithCache sets the response cache and its policy (DefaultCachePolicy if nil).
+func WithCache(cache Cache, policy *CachePolicy) Option {
+	return func(c *Client) error {
+		c.Cache = cache
+		c.CachePolicy = policy
+
+		return nil
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of concurrent lookups of the batch methods.
+func WithBatchConcurrency(workers int) Option {
+	return func(c *Client) error {
+		if workers < 1 {
+			return fmt.Errorf("invalid batch concurrency: %d", workers)
+		}
+
+		c.BatchConcurrency = workers
+
+		return nil
+	}
+}
+
+// WithMiddleware appends middlewares wrapping the HTTP client.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) error {
+		c.Middlewares = append(c.Middlewares, middlewares...)
+		return nil
+	}
+}
+
+// WithStrictDecoding makes the requests fail with a *SchemaDriftError on unknown response fields.
+func WithStrictDecoding() Option {
+	return func(c *Client) error {
+		c.StrictDecoding = true
+		return nil
+	}
+}
+
+// WithSchemaDriftHandler sets a function called with the unknown and the missing fields of each response.
+func WithSchemaDriftHandler(handler func(err *SchemaDriftError)) Option {
+	return func(c *Client) error {
+		c.SchemaDriftHandler = handler
+		return nil
+	}
+}
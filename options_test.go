@@ -0,0 +1,114 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_defaults(t *testing.T) {
+	client, err := NewClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultBaseURL, client.baseURL.String())
+	assert.Equal(t, 5*time.Second, client.HTTPClient.Timeout)
+	assert.Nil(t, client.RetryPolicy)
+	assert.Nil(t, client.RateLimiter)
+}
+
+func TestNewClient_options(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/mirror/asn/61138", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "test/1.0", req.UserAgent())
+		assert.Equal(t, "secret", req.Header.Get("X-Token"))
+		assert.Equal(t, "application/json", req.Header.Get("Accept"))
+
+		testHandler("asn.json")(rw, req)
+	})
+
+	httpClient := server.Client()
+
+	client, err := NewClient(
+		WithBaseURL(server.URL+"/mirror"),
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Minute),
+		WithUserAgent("test/1.0"),
+		WithHeader("X-Token", "secret"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Minute, client.HTTPClient.Timeout)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout, "the HTTP client must not be modified")
+
+	details, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, 61138, details.Data.ASN)
+}
+
+func TestNewClient_timeoutBeforeHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+
+	client, err := NewClient(WithTimeout(time.Second), WithHTTPClient(httpClient))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, client.HTTPClient.Timeout)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout, "the HTTP client must not be modified")
+}
+
+func TestNewClient_invalidOptions(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		option   Option
+		expected string
+	}{
+		{
+			desc:     "unparsable base URL",
+			option:   WithBaseURL("http://[::1"),
+			expected: `invalid base URL: parse "http://[::1": missing ']' in host`,
+		},
+		{
+			desc:     "base URL without scheme",
+			option:   WithBaseURL("api.bgpview.io"),
+			expected: `invalid base URL "api.bgpview.io": unsupported scheme ""`,
+		},
+		{
+			desc:     "base URL without host",
+			option:   WithBaseURL("https://"),
+			expected: `invalid base URL "https://": missing host`,
+		},
+		{
+			desc:     "nil HTTP client",
+			option:   WithHTTPClient(nil),
+			expected: "HTTP client must not be nil",
+		},
+		{
+			desc:     "negative timeout",
+			option:   WithTimeout(-time.Second),
+			expected: "invalid timeout: -1s",
+		},
+		{
+			desc:     "empty header key",
+			option:   WithHeader("", "value"),
+			expected: "header key must not be empty",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			client, err := NewClient(test.option)
+			require.EqualError(t, err, test.expected)
+
+			assert.Nil(t, client)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package bgpview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxMindCity is the MaxMind city of a prefix or an IP.
+// The API returns either a city name, an object or null (the zero value).
+type MaxMindCity struct {
+	Name      string  `json:"name,omitempty"`
+	Region    string  `json:"region,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+// IsZero reports whether the city is unknown.
+func (c MaxMindCity) IsZero() bool {
+	return c == MaxMindCity{}
+}
+
+// HasCoordinates reports whether the city has coordinates.
+func (c MaxMindCity) HasCoordinates() bool {
+	return c.Latitude != 0 || c.Longitude != 0
+}
+
+// MarshalJSON implements json.Marshaler.
+// An unknown city is encoded as null, a city with only a name as a string.
+func (c MaxMindCity) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.IsZero():
+		return []byte("null"), nil
+	case c == MaxMindCity{Name: c.Name}:
+		return json.Marshal(c.Name)
+	default:
+		type plain MaxMindCity
+		return json.Marshal(plain(c))
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *MaxMindCity) UnmarshalJSON(data []byte) error {
+	*c = MaxMindCity{}
+
+	switch firstByte(data) {
+	case 'n':
+		return nil
+
+	case '"':
+		return json.Unmarshal(data, &c.Name)
+
+	case '{':
+		var raw struct {
+			Name      string      `json:"name"`
+			City      string      `json:"city"`
+			Region    string      `json:"region"`
+			Latitude  json.Number `json:"latitude"`
+			Longitude json.Number `json:"longitude"`
+		}
+
+		err := json.Unmarshal(data, &raw)
+		if err != nil {
+			return fmt.Errorf("invalid MaxMind city: %w", err)
+		}
+
+		c.Name = raw.Name
+		if c.Name == "" {
+			c.Name = raw.City
+		}
+
+		c.Region = raw.Region
+		c.Latitude, _ = raw.Latitude.Float64()
+		c.Longitude, _ = raw.Longitude.Float64()
+
+		return nil
+
+	default:
+		return fmt.Errorf("invalid MaxMind city: %s", data)
+	}
+}
+
+// URLStats is the traffic statistics of an IX.
+// The API returns either the URL of the statistics page, an object or null (the zero value).
+type URLStats struct {
+	// URL is the URL of the statistics page.
+	URL string
+	// Stats are the other fields of an object payload, the non-string values in their JSON form.
+	Stats map[string]string
+}
+
+// IsZero reports whether there are no statistics.
+func (s URLStats) IsZero() bool {
+	return s.URL == "" && len(s.Stats) == 0
+}
+
+// MarshalJSON implements json.Marshaler.
+// No statistics are encoded as null, a URL alone as a string.
+func (s URLStats) MarshalJSON() ([]byte, error) {
+	if s.IsZero() {
+		return []byte("null"), nil
+	}
+
+	if len(s.Stats) == 0 {
+		return json.Marshal(s.URL)
+	}
+
+	object := make(map[string]string, len(s.Stats)+1)
+	for key, value := range s.Stats {
+		object[key] = value
+	}
+
+	if s.URL != "" {
+		object["url"] = s.URL
+	}
+
+	return json.Marshal(object)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *URLStats) UnmarshalJSON(data []byte) error {
+	*s = URLStats{}
+
+	switch firstByte(data) {
+	case 'n':
+		return nil
+
+	case '"':
+		return json.Unmarshal(data, &s.URL)
+
+	case '{':
+		var object map[string]json.RawMessage
+
+		err := json.Unmarshal(data, &object)
+		if err != nil {
+			return fmt.Errorf("invalid URL stats: %w", err)
+		}
+
+		for key, raw := range object {
+			if firstByte(raw) == 'n' {
+				continue
+			}
+
+			value := jsonText(raw)
+
+			if key == "url" {
+				s.URL = value
+				continue
+			}
+
+			if s.Stats == nil {
+				s.Stats = make(map[string]string)
+			}
+
+			s.Stats[key] = value
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("invalid URL stats: %s", data)
+	}
+}
+
+// firstByte returns the first non-space byte of a JSON value.
+func firstByte(data []byte) byte {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return 0
+	}
+
+	return data[0]
+}
+
+// jsonText returns the content of a JSON string, or the JSON form of the other values.
+func jsonText(raw json.RawMessage) string {
+	var value string
+
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return string(bytes.TrimSpace(raw))
+	}
+
+	return value
+}
@@ -0,0 +1,201 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxMindCity_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected MaxMindCity
+	}{
+		{
+			desc: "null",
+			data: `null`,
+		},
+		{
+			desc:     "name",
+			data:     `"Amsterdam"`,
+			expected: MaxMindCity{Name: "Amsterdam"},
+		},
+		{
+			desc:     "object",
+			data:     `{"name": "Santa Clara", "region": "California", "latitude": 37.3931, "longitude": -121.962}`,
+			expected: MaxMindCity{Name: "Santa Clara", Region: "California", Latitude: 37.3931, Longitude: -121.962},
+		},
+		{
+			desc:     "object with city key",
+			data:     `{"city": "Paris", "latitude": "48.8566", "longitude": null}`,
+			expected: MaxMindCity{Name: "Paris", Latitude: 48.8566},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var city MaxMindCity
+
+			err := json.Unmarshal([]byte(test.data), &city)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, city)
+		})
+	}
+}
+
+func TestMaxMindCity_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		city     MaxMindCity
+		expected string
+	}{
+		{desc: "zero", expected: `null`},
+		{desc: "name", city: MaxMindCity{Name: "Amsterdam"}, expected: `"Amsterdam"`},
+		{
+			desc:     "object",
+			city:     MaxMindCity{Name: "Santa Clara", Latitude: 37.3931, Longitude: -121.962},
+			expected: `{"name": "Santa Clara", "latitude": 37.3931, "longitude": -121.962}`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(test.city)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
+
+func TestURLStats_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected URLStats
+	}{
+		{
+			desc: "null",
+			data: `null`,
+		},
+		{
+			desc:     "URL",
+			data:     `"https://www.mixp.me/stats/"`,
+			expected: URLStats{URL: "https://www.mixp.me/stats/"},
+		},
+		{
+			desc: "object",
+			data: `{"url": "https://www.mixp.me/stats/", "traffic_peak": "12.5 Gbps", "members": 2, "graph": null}`,
+			expected: URLStats{
+				URL:   "https://www.mixp.me/stats/",
+				Stats: map[string]string{"traffic_peak": "12.5 Gbps", "members": "2"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var stats URLStats
+
+			err := json.Unmarshal([]byte(test.data), &stats)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, stats)
+		})
+	}
+}
+
+func TestURLStats_UnmarshalJSON_invalid(t *testing.T) {
+	var stats URLStats
+
+	err := json.Unmarshal([]byte(`[1, 2]`), &stats)
+	require.Error(t, err)
+
+	var city MaxMindCity
+
+	err = json.Unmarshal([]byte(`42`), &city)
+	require.Error(t, err)
+}
+
+func TestURLStats_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(URLStats{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `null`, string(data))
+
+	data, err = json.Marshal(URLStats{URL: "https://www.mixp.me/stats/"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"https://www.mixp.me/stats/"`, string(data))
+
+	data, err = json.Marshal(URLStats{URL: "https://www.mixp.me/stats/", Stats: map[string]string{"members": "2"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"url": "https://www.mixp.me/stats/", "members": "2"}`, string(data))
+}
+
+func TestClient_GetIP_maxmind(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip-maxmind.json"))
+
+	ip, err := client.GetIP(context.Background(), "2a05:dfc7:60::")
+	require.NoError(t, err)
+
+	expected := MaxMindData{
+		CountryCode: "US",
+		City:        MaxMindCity{Name: "Santa Clara", Region: "California", Latitude: 37.3931, Longitude: -121.962},
+	}
+	assert.Equal(t, expected, ip.Data.MaxMind)
+	assert.True(t, ip.Data.MaxMind.City.HasCoordinates())
+}
+
+func TestClient_GetPrefix_maxmind(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/prefix/192.209.63.0/24", testHandler("prefix-maxmind.json"))
+
+	prefix, err := client.GetPrefix(context.Background(), "192.209.63.0", 24)
+	require.NoError(t, err)
+
+	assert.Equal(t, MaxMindData{CountryCode: "US", City: MaxMindCity{Name: "Santa Clara"}}, prefix.Data.MaxMind)
+	assert.False(t, prefix.Data.MaxMind.City.HasCoordinates())
+}
+
+func TestClient_GetIX_urlStats(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ix/492", testHandler("ix-url-stats.json"))
+
+	ix, err := client.GetIX(context.Background(), 492)
+	require.NoError(t, err)
+
+	expected := URLStats{
+		URL:   "https://www.mixp.me/stats/",
+		Stats: map[string]string{"traffic_peak": "12.5 Gbps", "members": "2"},
+	}
+	assert.Equal(t, expected, ix.Data.URLStats)
+}
+
+func TestClient_GetIX_nullURLStats(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ix/492", testHandler("ix.json"))
+
+	ix, err := client.GetIX(context.Background(), 492)
+	require.NoError(t, err)
+
+	assert.True(t, ix.Data.URLStats.IsZero())
+	assert.Equal(t, URLStats{}, ix.Data.URLStats)
+}
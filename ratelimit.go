@@ -0,0 +1,141 @@
+package bgpview
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint families, used to configure per-endpoint behaviors.
+const (
+	FamilyASN    = "asn"
+	FamilyPrefix = "prefix"
+	FamilyIP     = "ip"
+	FamilyIX     = "ix"
+	FamilySearch = "search"
+)
+
+// Limit defines a request rate.
+type Limit struct {
+	// RequestsPerSecond is the sustained rate. A value <= 0 means no limit.
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests allowed at once (at least 1).
+	Burst int
+}
+
+// RateLimiter is a token bucket rate limiter shared by all the requests of a Client.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	fallback *bucket
+	families map[string]*bucket
+}
+
+// NewRateLimiter creates a new RateLimiter.
+// The limit applies to all the endpoint families without a dedicated limit.
+func NewRateLimiter(limit Limit) *RateLimiter {
+	return &RateLimiter{
+		fallback: newBucket(limit),
+		families: make(map[string]*bucket),
+	}
+}
+
+// SetLimit sets a dedicated limit for an endpoint family (FamilyASN, FamilySearch, ...).
+func (l *RateLimiter) SetLimit(family string, limit Limit) *RateLimiter {
+	l.mu.Lock()
+	l.families[family] = newBucket(limit)
+	l.mu.Unlock()
+
+	return l
+}
+
+// Wait blocks until a request to the endpoint family is allowed or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, family string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	b, ok := l.families[family]
+	if !ok {
+		b = l.fallback
+	}
+	l.mu.Unlock()
+
+	return b.wait(ctx)
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit Limit) *bucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &bucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	if b.limit.RequestsPerSecond <= 0 {
+		return ctx.Err()
+	}
+
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	err := sleep(ctx, delay)
+	if err != nil {
+		b.cancel()
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token and returns the delay to wait before using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.RequestsPerSecond
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// cancel gives back a reserved token.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// endpointFamily returns the endpoint family of an endpoint (e.g. "asn" for "/asn/61138/prefixes").
+func endpointFamily(baseURL, endpoint *url.URL) string {
+	return endpointSegments(baseURL, endpoint)[0]
+}
+
+// endpointSegments returns the path segments of an endpoint, relative to the base URL.
+func endpointSegments(baseURL, endpoint *url.URL) []string {
+	p := strings.TrimPrefix(endpoint.Path, strings.TrimSuffix(baseURL.Path, "/"))
+
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
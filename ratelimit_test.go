@@ -0,0 +1,92 @@
+package bgpview
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_rateLimiter(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RateLimiter = NewRateLimiter(Limit{RequestsPerSecond: 20, Burst: 2}).
+		SetLimit(FamilySearch, Limit{RequestsPerSecond: 1000, Burst: 10})
+
+	mux.HandleFunc("/asn/", testHandler("asn.json"))
+	mux.HandleFunc("/search", testHandler("search.json"))
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func(asNumber int) {
+			defer wg.Done()
+
+			_, err := client.GetASN(context.Background(), asNumber)
+			assert.NoError(t, err)
+		}(61138 + i)
+	}
+
+	wg.Wait()
+
+	// 2 requests from the burst, then 4 requests at 20 requests per second.
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+
+	start = time.Now()
+
+	for i := 0; i < 10; i++ {
+		_, err := client.GetSearch(context.Background(), "digitalocean")
+		require.NoError(t, err)
+	}
+
+	assert.Less(t, time.Since(start), 150*time.Millisecond, "search has its own limit")
+}
+
+func TestRateLimiter_Wait_contextCanceled(t *testing.T) {
+	limiter := NewRateLimiter(Limit{RequestsPerSecond: 0.1, Burst: 1})
+
+	require.NoError(t, limiter.Wait(context.Background(), FamilyASN))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx, FamilyASN)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_Wait_unlimited(t *testing.T) {
+	limiter := NewRateLimiter(Limit{})
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), FamilyIP))
+	}
+}
+
+func Test_endpointFamily(t *testing.T) {
+	testCases := []struct {
+		baseURL  string
+		endpoint string
+		expected string
+	}{
+		{baseURL: "https://api.bgpview.io", endpoint: "https://api.bgpview.io/asn/61138/prefixes", expected: FamilyASN},
+		{baseURL: "https://api.bgpview.io/", endpoint: "https://api.bgpview.io/search?query_term=test", expected: FamilySearch},
+		{baseURL: "https://example.com/bgpview", endpoint: "https://example.com/bgpview/prefix/192.0.2.0/24", expected: FamilyPrefix},
+	}
+
+	for _, test := range testCases {
+		baseURL, err := url.Parse(test.baseURL)
+		require.NoError(t, err)
+
+		endpoint, err := url.Parse(test.endpoint)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expected, endpointFamily(baseURL, endpoint))
+	}
+}
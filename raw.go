@@ -0,0 +1,103 @@
+package bgpview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// RawResponse is a response with the payload it was decoded from.
+//
+// Every struct tag of the response types uses omitempty, so encoding a response drops its null and zero values.
+// RawResponse is encoded as its payload instead, which round-trips the original payload.
+type RawResponse[T any] struct {
+	// Response is the decoded response (e.g. *ASNPrefixesInfo).
+	Response T
+	// Raw is the payload.
+	// Set it to nil to encode Response instead (e.g. after modifying it).
+	Raw json.RawMessage
+}
+
+// Decoded returns the decoded response.
+func (r *RawResponse[T]) Decoded() interface{} {
+	return r.Response
+}
+
+// MarshalJSON encodes the payload, or the response if there is no payload.
+func (r RawResponse[T]) MarshalJSON() ([]byte, error) {
+	if r.Raw != nil {
+		return r.Raw, nil
+	}
+
+	return json.Marshal(r.Response)
+}
+
+// UnmarshalJSON decodes the response, and keeps a copy of the payload.
+func (r *RawResponse[T]) UnmarshalJSON(data []byte) error {
+	var response T
+
+	err := json.Unmarshal(data, &response)
+	if err != nil {
+		return err
+	}
+
+	r.Response = response
+	r.Raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// Raw calls a Client method making a single request (e.g. client.GetASNPrefixes),
+// and returns its response with the payload it was decoded from.
+//
+//	resp, err := bgpview.Raw(ctx, client.GetASNPrefixes, 61138)
+//
+// The payload is nil if the method is not a Client method (e.g. a mock of API).
+// An error is returned if the method made several requests (e.g. client.GetASNs, client.Enrich).
+func Raw[A, T any](ctx context.Context, get func(ctx context.Context, arg A) (T, error), arg A) (*RawResponse[T], error) {
+	capture := &rawCapture{}
+
+	response, err := get(context.WithValue(ctx, rawCaptureKey{}, capture), arg)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, count := capture.payload()
+	if count > 1 {
+		return nil, fmt.Errorf("bgpview: raw response of a method making %d requests: a single payload can't represent it", count)
+	}
+
+	return &RawResponse[T]{Response: response, Raw: payload}, nil
+}
+
+type rawCaptureKey struct{}
+
+// rawCapture receives the payloads of the requests made with its context.
+type rawCapture struct {
+	mu    sync.Mutex
+	body  json.RawMessage
+	count int
+}
+
+// captureRaw keeps a copy of a payload, if the context asks for it.
+func captureRaw(ctx context.Context, body []byte) {
+	capture, ok := ctx.Value(rawCaptureKey{}).(*rawCapture)
+	if !ok {
+		return
+	}
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+
+	capture.count++
+	capture.body = append(json.RawMessage(nil), body...)
+}
+
+// payload returns the last captured payload, and the number of captured payloads.
+func (c *rawCapture) payload() (json.RawMessage, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.body, c.count
+}
@@ -0,0 +1,231 @@
+package bgpview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readFixture returns the payload of a fixture.
+func readFixture(t *testing.T, filename string) json.RawMessage {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("bgpviewtest", "fixtures", filename))
+	require.NoError(t, err)
+
+	return bytes.TrimSpace(data)
+}
+
+// newRawResponse returns a new RawResponse of the response type of a fixture.
+func newRawResponse(filename string) interface{} {
+	switch name := strings.TrimSuffix(filename, ".json"); {
+	case strings.HasPrefix(name, "asn-prefixes"):
+		return &RawResponse[*ASNPrefixesInfo]{}
+	case strings.HasPrefix(name, "asn-peers"):
+		return &RawResponse[*ASNPeersInfo]{}
+	case strings.HasPrefix(name, "asn-upstreams"):
+		return &RawResponse[*ASNUpstreamsInfo]{}
+	case strings.HasPrefix(name, "asn-downstreams"):
+		return &RawResponse[*ASNDownstreamsInfo]{}
+	case strings.HasPrefix(name, "asn-ixs"):
+		return &RawResponse[*ASNIxsInfo]{}
+	case strings.HasPrefix(name, "asn"):
+		return &RawResponse[*ASNInfo]{}
+	case strings.HasPrefix(name, "prefix"):
+		return &RawResponse[*PrefixInfo]{}
+	case strings.HasPrefix(name, "ip"):
+		return &RawResponse[*IPInfo]{}
+	case strings.HasPrefix(name, "ix"):
+		return &RawResponse[*IXInfo]{}
+	case strings.HasPrefix(name, "search"):
+		return &RawResponse[*SearchInfo]{}
+	default:
+		return nil
+	}
+}
+
+func TestRawResponse_roundTrip(t *testing.T) {
+	filenames, err := filepath.Glob(filepath.Join("bgpviewtest", "fixtures", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, filenames)
+
+	for _, filename := range filenames {
+		filename := filepath.Base(filename)
+		t.Run(filename, func(t *testing.T) {
+			t.Parallel()
+
+			response := newRawResponse(filename)
+			require.NotNil(t, response, "no response type for the fixture")
+
+			original := readFixture(t, filename)
+
+			err := json.Unmarshal(original, response)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(original), string(data))
+
+			indented, err := json.MarshalIndent(response, "", "  ")
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(original), string(indented))
+
+			// The response type itself round-trips, without the payload.
+			decoded := response.(interface{ Decoded() interface{} }).Decoded()
+
+			encoded, err := json.Marshal(decoded)
+			require.NoError(t, err)
+
+			again := reflect.New(reflect.TypeOf(decoded).Elem()).Interface()
+
+			err = json.Unmarshal(encoded, again)
+			require.NoError(t, err)
+
+			// omitempty drops the empty lists, which is the only expected loss.
+			assert.Equal(t, nilEmpty(decoded), again)
+		})
+	}
+}
+
+// nilEmpty returns a copy of a response with the empty slices and maps set to nil.
+func nilEmpty(response interface{}) interface{} {
+	value := reflect.New(reflect.TypeOf(response).Elem())
+	value.Elem().Set(reflect.ValueOf(response).Elem())
+
+	var walk func(v reflect.Value)
+
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+
+		case reflect.Slice:
+			if v.Len() == 0 {
+				v.Set(reflect.Zero(v.Type()))
+				return
+			}
+
+			copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(copied, v)
+			v.Set(copied)
+
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+
+		case reflect.Map:
+			if v.Len() == 0 {
+				v.Set(reflect.Zero(v.Type()))
+			}
+
+		default:
+		}
+	}
+
+	walk(value)
+
+	return value.Interface()
+}
+
+func TestRawResponse_withoutPayload(t *testing.T) {
+	var response RawResponse[*IXInfo]
+
+	err := json.Unmarshal(readFixture(t, "ix.json"), &response)
+	require.NoError(t, err)
+
+	response.Response.Data.Name = "MIXP"
+	response.Raw = nil
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var decoded IXInfo
+
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, "MIXP", decoded.Data.Name)
+}
+
+func TestRaw(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", testHandler("ip.json"))
+
+	response, err := Raw(context.Background(), client.GetIP, "2a05:dfc7:60::")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(readFixture(t, "ip.json")), string(bytes.TrimSpace(response.Raw)))
+	assert.Equal(t, "US-ZAPPIE-20150303", response.Response.Data.Prefixes[0].Name)
+	assert.Same(t, response.Response, response.Decoded())
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(readFixture(t, "ip.json")), string(data))
+
+	// The responses don't keep their payload.
+	plain, err := client.GetIP(context.Background(), "2a05:dfc7:60::")
+	require.NoError(t, err)
+
+	assert.Equal(t, response.Response, plain)
+}
+
+func TestRaw_notClient(t *testing.T) {
+	get := func(_ context.Context, asNumber int) (*ASNInfo, error) {
+		return &ASNInfo{Status: statusOK, Data: ASNData{ASN: asNumber}}, nil
+	}
+
+	response, err := Raw(context.Background(), get, 61138)
+	require.NoError(t, err)
+
+	assert.Nil(t, response.Raw)
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(response.Response)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(data))
+}
+
+func TestRaw_severalRequests(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/asn/", testHandler("asn.json"))
+
+	_, err := Raw(context.Background(), client.GetASNs, []int{1, 2, 3})
+	require.EqualError(t, err, "bgpview: raw response of a method making 3 requests: a single payload can't represent it")
+
+	response, err := Raw(context.Background(), client.GetASNs, []int{61138})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(readFixture(t, "asn.json")), string(response.Raw))
+}
+
+func TestRaw_error(t *testing.T) {
+	client, _ := setupTest(t)
+
+	_, err := Raw(context.Background(), client.GetASN, 1)
+	require.ErrorIs(t, err, ErrNotFound)
+}
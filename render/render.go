@@ -0,0 +1,251 @@
+// Package render renders the BGPView responses as tables, JSON, NDJSON, CSV or YAML.
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format.
+type Format string
+
+// Output formats.
+const (
+	// FormatTable is an aligned human-readable table.
+	// A single row is rendered vertically, as field/value lines.
+	FormatTable Format = "table"
+	// FormatJSON is the indented JSON of the response.
+	FormatJSON Format = "json"
+	// FormatNDJSON is a JSON object by row (newline-delimited JSON).
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV is a CSV with a header line.
+	FormatCSV Format = "csv"
+	// FormatYAML is the YAML of the response.
+	FormatYAML Format = "yaml"
+)
+
+// Formats are the supported output formats.
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON, FormatCSV, FormatYAML}
+
+// ParseFormat parses an output format (case-insensitive).
+func ParseFormat(s string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(s, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown format %q", s)
+}
+
+// Render writes a response (e.g. *bgpview.ASNInfo) in a format.
+// JSON and YAML render the whole response, the other formats render its rows (see Flatten).
+func Render(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, v)
+	case FormatYAML:
+		return writeYAML(w, v)
+	case FormatTable, FormatNDJSON, FormatCSV:
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	table, err := Flatten(v)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatNDJSON:
+		return table.WriteNDJSON(w)
+	case FormatCSV:
+		return table.WriteCSV(w)
+	default:
+		return table.WriteText(w)
+	}
+}
+
+// WriteText writes an aligned table, or field/value lines for a single row.
+func (t *Table) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if len(t.Rows) == 1 {
+		for i, name := range t.Columns {
+			fmt.Fprintf(tw, "%s\t%s\n", name, textCell(t.Rows[0][i]))
+		}
+
+		return tw.Flush()
+	}
+
+	header := make([]string, len(t.Columns))
+	for i, name := range t.Columns {
+		header[i] = strings.ToUpper(name)
+	}
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = textCell(value)
+		}
+
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// WriteCSV writes a CSV with a header line.
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(t.Columns)
+	if err != nil {
+		return err
+	}
+
+	return t.writeCSVRows(cw)
+}
+
+func (t *Table) writeCSVRows(cw *csv.Writer) error {
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = text(value)
+		}
+
+		err := cw.Write(cells)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteNDJSON writes a JSON object by row, with the keys in the column order.
+func (t *Table) WriteNDJSON(w io.Writer) error {
+	for _, row := range t.Rows {
+		var buf bytes.Buffer
+
+		buf.WriteByte('{')
+
+		for i, name := range t.Columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, err := json.Marshal(name)
+			if err != nil {
+				return err
+			}
+
+			value, err := json.Marshal(row[i])
+			if err != nil {
+				return err
+			}
+
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+
+		buf.WriteString("}\n")
+
+		_, err := w.Write(buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// writeYAML writes the YAML of the JSON of a value, so the keys and the values are the same as in JSON.
+func writeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return err
+	}
+
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(&node)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// blockStyle resets the JSON (flow) style of the nodes.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// text returns the text of a cell value, the lists being joined by ";".
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = text(item)
+		}
+
+		return strings.Join(values, ";")
+	case json.RawMessage:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// textCell returns the text of a cell value on a single line.
+func textCell(value interface{}) string {
+	return strings.Join(strings.Fields(text(value)), " ")
+}
@@ -0,0 +1,153 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/electrologue/bgpview"
+	"github.com/electrologue/bgpview/bgpviewtest"
+	"github.com/electrologue/bgpview/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for value, expected := range map[string]render.Format{"table": render.FormatTable, "JSON": render.FormatJSON, "ndjson": render.FormatNDJSON, "Csv": render.FormatCSV, "yaml": render.FormatYAML} {
+		format, err := render.ParseFormat(value)
+		require.NoError(t, err, value)
+
+		assert.Equal(t, expected, format, value)
+	}
+
+	_, err := render.ParseFormat("xml")
+	require.EqualError(t, err, `unknown format "xml"`)
+}
+
+func TestRender_json(t *testing.T) {
+	info := decodeFixture[bgpview.ASNInfo](t, "asn.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatJSON, info)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(bgpviewtest.Fixture("asn.json")), buf.String())
+	assert.True(t, strings.HasPrefix(buf.String(), "{\n  \"status\""), "indented")
+}
+
+func TestRender_yaml(t *testing.T) {
+	info := decodeFixture[bgpview.ASNInfo](t, "asn.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatYAML, info)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "status: ok\nstatus_message: Query was successful\ndata:\n  asn: 61138\n"), output)
+	assert.Contains(t, output, "  email_contacts:\n    - abuse@zappiehost.com\n")
+	assert.Contains(t, output, "  date_updated: \"2021-11-21 04:02:05\"\n")
+}
+
+func TestRender_table(t *testing.T) {
+	info := decodeFixture[bgpview.ASNIxsInfo](t, "asn-ixs.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatTable, info)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(info.Data)+1)
+
+	assert.Equal(t, []string{"IX_ID", "NAME", "NAME_FULL", "COUNTRY_CODE", "CITY", "IPV4_ADDRESS", "IPV6_ADDRESS", "SPEED"}, strings.Fields(lines[0]))
+	assert.True(t, strings.HasPrefix(lines[1], "585    EVIX "), lines[1])
+}
+
+func TestRender_table_single(t *testing.T) {
+	info := decodeFixture[bgpview.ASNInfo](t, "asn.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatTable, info)
+	require.NoError(t, err)
+
+	lines := strings.Split(buf.String(), "\n")
+
+	assert.Equal(t, []string{"asn", "61138"}, strings.Fields(lines[0]))
+	assert.Contains(t, lines, "email_contacts                     abuse@zappiehost.com;admin@zappiehost.com;noc@zappiehost.com")
+	assert.Contains(t, lines, "rir_allocation.rir_name            RIPE")
+}
+
+func TestRender_table_nil(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatTable, &bgpview.IXInfo{})
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "null")
+}
+
+func TestRender_csv(t *testing.T) {
+	info := decodeFixture[bgpview.SearchInfo](t, "search.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatCSV, info)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(info.Data.ASNs)+len(info.Data.IPv4Prefixes)+len(info.Data.IPv6Prefixes)+len(info.Data.IXs)+1)
+
+	assert.True(t, strings.HasPrefix(lines[0], "section,asn,name,description,country_code,email_contacts,"), lines[0])
+	assert.Equal(t, `asns,133165,DIGITALOCEAN-AS-AP,"Digital Ocean, Inc.",SG,abuse@digitalocean.com,abuse@digitalocean.com,APNIC,,,,,,,,,`, lines[1])
+}
+
+func TestRender_ndjson(t *testing.T) {
+	info := decodeFixture[bgpview.ASNPrefixesInfo](t, "asn-prefixes.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatNDJSON, info)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(info.Data.IPv4Prefixes)+len(info.Data.IPv6Prefixes))
+
+	// The keys are in the column order.
+	expected := `{"section":"ipv4_prefixes","prefix":"45.67.13.0/24","ip":"45.67.13.0","cidr":24,"roa_status":"None",` +
+		`"name":"QUICKVIRT-PRA01","description":"QUICKVIRT PRA01","country_code":"CZ",` +
+		`"parent.prefix":"45.67.12.0/22","parent.ip":"45.67.12.0","parent.cidr":22,"parent.rir_name":"RIPE","parent.allocation_status":"unknown"}`
+	assert.Equal(t, expected, lines[0])
+}
+
+func TestRender_errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.Format("xml"), struct{}{})
+	require.EqualError(t, err, `unknown format "xml"`)
+
+	err = render.Render(&buf, render.FormatCSV, "text")
+	require.Error(t, err)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestRender_rawResponse(t *testing.T) {
+	response := decodeFixture[bgpview.RawResponse[*bgpview.ASNPrefixesInfo]](t, "asn-prefixes.json")
+
+	var buf bytes.Buffer
+
+	err := render.Render(&buf, render.FormatJSON, response)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(bgpviewtest.Fixture("asn-prefixes.json")), buf.String())
+
+	buf.Reset()
+
+	err = render.Render(&buf, render.FormatCSV, response)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(buf.String(), "section,prefix,"), buf.String())
+}
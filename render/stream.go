@@ -0,0 +1,59 @@
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Stream writes values as rows as they come, in CSV or NDJSON.
+// The values are flattened as by Flatten, and must have the same columns:
+// the CSV header is written before the rows of the first value.
+type Stream struct {
+	w      io.Writer
+	csv    *csv.Writer
+	format Format
+	header bool
+}
+
+// NewStream creates a Stream writing in a format: FormatCSV or FormatNDJSON.
+func NewStream(w io.Writer, format Format) (*Stream, error) {
+	switch format {
+	case FormatCSV:
+		return &Stream{w: w, csv: csv.NewWriter(w), format: format}, nil
+	case FormatNDJSON:
+		return &Stream{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("format %q cannot be streamed", format)
+	}
+}
+
+// Write writes the rows of a value.
+func (s *Stream) Write(v interface{}) error {
+	table, err := Flatten(v)
+	if err != nil {
+		return err
+	}
+
+	if s.format == FormatNDJSON {
+		return table.WriteNDJSON(s.w)
+	}
+
+	if !s.header {
+		err = s.csv.Write(table.Columns)
+		if err != nil {
+			return err
+		}
+
+		s.header = true
+	}
+
+	err = table.writeCSVRows(s.csv)
+	if err != nil {
+		return err
+	}
+
+	s.csv.Flush()
+
+	return s.csv.Error()
+}
@@ -0,0 +1,48 @@
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/electrologue/bgpview/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamRow struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+	Err    error    `json:"error"`
+}
+
+func TestStream_csv(t *testing.T) {
+	var buf bytes.Buffer
+
+	stream, err := render.NewStream(&buf, render.FormatCSV)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Write(&streamRow{Name: "a", Values: []string{"1", "2"}}))
+	require.NoError(t, stream.Write(streamRow{Name: "b, c", Err: errors.New("failed")}))
+
+	assert.Equal(t, "name,values,error\na,1;2,\n\"b, c\",,failed\n", buf.String())
+}
+
+func TestStream_ndjson(t *testing.T) {
+	var buf bytes.Buffer
+
+	stream, err := render.NewStream(&buf, render.FormatNDJSON)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Write(&streamRow{Name: "a", Values: []string{"1"}}))
+	require.NoError(t, stream.Write([]streamRow{{Name: "b", Err: errors.New("failed")}}))
+
+	assert.Equal(t, `{"name":"a","values":["1"],"error":null}`+"\n"+`{"name":"b","values":[],"error":"failed"}`+"\n", buf.String())
+}
+
+func TestNewStream_unsupported(t *testing.T) {
+	for _, format := range []render.Format{render.FormatTable, render.FormatJSON, render.FormatYAML} {
+		_, err := render.NewStream(&bytes.Buffer{}, format)
+		assert.Error(t, err, format)
+	}
+}
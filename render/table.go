@@ -0,0 +1,365 @@
+package render
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/electrologue/bgpview"
+)
+
+// SectionColumn is the name of the column holding the section of the rows,
+// when a response has several lists (e.g. "ipv4_prefixes" and "ipv6_prefixes").
+const SectionColumn = "section"
+
+// Table is a response flattened into rows.
+//
+// The flattening rules are:
+//   - the columns are named after the JSON fields, in the order of the struct fields;
+//   - the fields of nested structs are prefixed by the name of the struct field (e.g. "parent.prefix");
+//   - the types with their own text or JSON representation (Timestamp, ASPath, MaxMindCity, ...) are single columns;
+//   - the lists of values are single columns, joined by ";" in the text formats;
+//   - the lists of structs are single columns holding JSON;
+//   - the errors are single columns holding their message.
+type Table struct {
+	Columns []string
+	// Rows are the values of the columns: nil, bool, numbers, strings,
+	// []interface{} for the lists of values, or json.RawMessage for the other values.
+	Rows [][]interface{}
+}
+
+type section struct {
+	name  string
+	items reflect.Value
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Flatten flattens a response (e.g. *bgpview.ASNPrefixesInfo) or any struct or slice of structs into a Table.
+//
+// The responses with lists have a row by list item: the ASN prefixes, peers, upstreams and downstreams,
+// the ASN IXs, and the search results.
+// The other responses have a single row.
+func Flatten(v interface{}) (*Table, error) {
+	sections, err := sectionsOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{}
+
+	if len(sections) > 1 {
+		table.Columns = append(table.Columns, SectionColumn)
+	}
+
+	// The columns of the sections, by element type.
+	columnsByType := make(map[reflect.Type][]column)
+	seen := make(map[string]bool)
+
+	for _, s := range sections {
+		typ := s.items.Type().Elem()
+		if _, ok := columnsByType[typ]; ok {
+			continue
+		}
+
+		columns := typeColumns(typ, "", nil)
+		columnsByType[typ] = columns
+
+		for _, c := range columns {
+			if !seen[c.name] {
+				seen[c.name] = true
+				table.Columns = append(table.Columns, c.name)
+			}
+		}
+	}
+
+	positions := make(map[string]int, len(table.Columns))
+	for i, name := range table.Columns {
+		positions[name] = i
+	}
+
+	for _, s := range sections {
+		columns := columnsByType[s.items.Type().Elem()]
+
+		for i := 0; i < s.items.Len(); i++ {
+			row := make([]interface{}, len(table.Columns))
+
+			if len(sections) > 1 {
+				row[0] = s.name
+			}
+
+			item := s.items.Index(i)
+			for _, c := range columns {
+				row[positions[c.name]] = cellValue(fieldByIndex(item, c.index))
+			}
+
+			table.Rows = append(table.Rows, row)
+		}
+	}
+
+	return table, nil
+}
+
+// sectionsOf returns the lists of rows of a value.
+func sectionsOf(v interface{}) ([]section, error) {
+	// The responses with their payload (bgpview.RawResponse) are flattened from the decoded response.
+	if decoded, ok := v.(interface{ Decoded() interface{} }); ok {
+		v = decoded.Decoded()
+	}
+
+	switch resp := v.(type) {
+	case *bgpview.ASNPrefixesInfo:
+		return []section{
+			{name: "ipv4_prefixes", items: reflect.ValueOf(resp.Data.IPv4Prefixes)},
+			{name: "ipv6_prefixes", items: reflect.ValueOf(resp.Data.IPv6Prefixes)},
+		}, nil
+
+	case *bgpview.ASNPeersInfo:
+		return []section{
+			{name: "ipv4_peers", items: reflect.ValueOf(resp.Data.IPv4Peers)},
+			{name: "ipv6_peers", items: reflect.ValueOf(resp.Data.IPv6Peers)},
+		}, nil
+
+	case *bgpview.ASNUpstreamsInfo:
+		return []section{
+			{name: "ipv4_upstreams", items: reflect.ValueOf(resp.Data.IPv4Upstreams)},
+			{name: "ipv6_upstreams", items: reflect.ValueOf(resp.Data.IPv6Upstreams)},
+		}, nil
+
+	case *bgpview.ASNDownstreamsInfo:
+		return []section{
+			{name: "ipv4_downstreams", items: reflect.ValueOf(resp.Data.IPv4Downstreams)},
+			{name: "ipv6_downstreams", items: reflect.ValueOf(resp.Data.IPv6Downstreams)},
+		}, nil
+
+	case *bgpview.SearchInfo:
+		return []section{
+			{name: "asns", items: reflect.ValueOf(resp.Data.ASNs)},
+			{name: "ipv4_prefixes", items: reflect.ValueOf(resp.Data.IPv4Prefixes)},
+			{name: "ipv6_prefixes", items: reflect.ValueOf(resp.Data.IPv6Prefixes)},
+			{name: "internet_exchanges", items: reflect.ValueOf(resp.Data.IXs)},
+		}, nil
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("cannot flatten nil %T", v)
+		}
+
+		value = value.Elem()
+	}
+
+	// The responses (e.g. bgpview.ASNIxsInfo, bgpview.IPInfo) are flattened from their data.
+	if value.Kind() == reflect.Struct {
+		if data := value.FieldByName("Data"); data.IsValid() && value.FieldByName("Meta").IsValid() {
+			value = data
+		}
+	}
+
+	switch {
+	case value.Kind() == reflect.Slice && isStruct(value.Type().Elem()):
+		return []section{{items: value}}, nil
+
+	case value.Kind() == reflect.Struct:
+		items := reflect.MakeSlice(reflect.SliceOf(value.Type()), 0, 1)
+		return []section{{items: reflect.Append(items, value)}}, nil
+
+	default:
+		return nil, fmt.Errorf("cannot flatten %T: not a struct or a slice of structs", v)
+	}
+}
+
+// typeColumns returns the columns of a struct type.
+func typeColumns(typ reflect.Type, prefix string, index []int) []column {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	var columns []column
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if field.Anonymous && name == "" && isStruct(field.Type) && !isLeaf(field.Type) {
+			columns = append(columns, typeColumns(field.Type, prefix, fieldIndex)...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if isStruct(field.Type) && !isLeaf(field.Type) {
+			columns = append(columns, typeColumns(field.Type, prefix+name+".", fieldIndex)...)
+			continue
+		}
+
+		columns = append(columns, column{name: prefix + name, index: fieldIndex})
+	}
+
+	return columns
+}
+
+// fieldByIndex returns a nested field, or an invalid value if a pointer on the way is nil.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// cellValue converts a field value into a cell value.
+// The errors are their message.
+func cellValue(v reflect.Value) interface{} {
+	if v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() && v.Type().Implements(errorType) {
+		return v.Interface().(error).Error()
+	}
+
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	if isLeaf(v.Type()) {
+		return leafValue(v)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		elem := v.Type().Elem()
+		if isStruct(elem) && !isLeaf(elem) {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return nil
+			}
+
+			return marshalRaw(v)
+		}
+
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = cellValue(v.Index(i))
+		}
+
+		return values
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		return marshalRaw(v)
+	default:
+		return marshalRaw(v)
+	}
+}
+
+// leafValue returns the value of a type with its own representation.
+func leafValue(v reflect.Value) interface{} {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+
+	case v.Type().Implements(stringerType):
+		s := v.Interface().(fmt.Stringer).String()
+		if s == "" {
+			return nil
+		}
+
+		return s
+
+	default:
+		raw := marshalRaw(v)
+		if string(raw) == "null" {
+			return nil
+		}
+
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+
+		return raw
+	}
+}
+
+func marshalRaw(v reflect.Value) json.RawMessage {
+	raw, err := json.Marshal(v.Interface())
+	if err != nil {
+		return json.RawMessage(strconv.Quote(err.Error()))
+	}
+
+	return raw
+}
+
+func isStruct(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Struct
+}
+
+// isLeaf reports whether a type has its own text or JSON representation.
+func isLeaf(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	for _, t := range []reflect.Type{typ, reflect.PointerTo(typ)} {
+		if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) || t.Implements(stringerType) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,161 @@
+package render_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/electrologue/bgpview"
+	"github.com/electrologue/bgpview/bgpviewtest"
+	"github.com/electrologue/bgpview/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeFixture[T any](t *testing.T, filename string) *T {
+	t.Helper()
+
+	v := new(T)
+
+	err := json.Unmarshal(bgpviewtest.Fixture(filename), v)
+	require.NoError(t, err)
+
+	return v
+}
+
+func TestFlatten_ASNPrefixesInfo(t *testing.T) {
+	info := decodeFixture[bgpview.ASNPrefixesInfo](t, "asn-prefixes.json")
+
+	table, err := render.Flatten(info)
+	require.NoError(t, err)
+
+	expected := []string{
+		"section", "prefix", "ip", "cidr", "roa_status", "name", "description", "country_code",
+		"parent.prefix", "parent.ip", "parent.cidr", "parent.rir_name", "parent.allocation_status",
+	}
+	assert.Equal(t, expected, table.Columns)
+
+	require.Len(t, table.Rows, len(info.Data.IPv4Prefixes)+len(info.Data.IPv6Prefixes))
+
+	first := table.Rows[0]
+	assert.Equal(t, []interface{}{
+		"ipv4_prefixes", "45.67.13.0/24", "45.67.13.0", int64(24), "None", "QUICKVIRT-PRA01", "QUICKVIRT PRA01", "CZ",
+		"45.67.12.0/22", "45.67.12.0", int64(22), "RIPE", "unknown",
+	}, first)
+
+	last := table.Rows[len(table.Rows)-1]
+	assert.Equal(t, "ipv6_prefixes", last[0])
+	assert.Equal(t, "2c0f:f530:20::/44", last[1])
+}
+
+func TestFlatten_SearchInfo(t *testing.T) {
+	info := decodeFixture[bgpview.SearchInfo](t, "search.json")
+
+	table, err := render.Flatten(info)
+	require.NoError(t, err)
+
+	// The columns are the union of the columns of the sections.
+	assert.Equal(t, "section", table.Columns[0])
+	assert.Subset(t, table.Columns, []string{"asn", "email_contacts", "prefix", "parent_prefix", "ix_id", "city"})
+
+	column := indexOf(table.Columns, "email_contacts")
+	require.NotEqual(t, -1, column)
+
+	asn := table.Rows[0]
+	assert.Equal(t, "asns", asn[0])
+	assert.Equal(t, []interface{}{"abuse@digitalocean.com"}, asn[column])
+	assert.Nil(t, asn[indexOf(table.Columns, "prefix")])
+}
+
+func TestFlatten_single(t *testing.T) {
+	info := decodeFixture[bgpview.PrefixInfo](t, "prefix.json")
+
+	table, err := render.Flatten(info)
+	require.NoError(t, err)
+
+	require.Len(t, table.Rows, 1)
+	assert.NotContains(t, table.Columns, "section")
+
+	row := table.Rows[0]
+
+	assert.Equal(t, "192.209.63.0/24", row[indexOf(table.Columns, "prefix")])
+	assert.Equal(t, "2015-04-28 00:00:00", row[indexOf(table.Columns, "rir_allocation.date_allocated")])
+	assert.Equal(t, int64(23), row[indexOf(table.Columns, "rir_allocation.cidr")])
+
+	// The lists of structs are JSON.
+	asns := row[indexOf(table.Columns, "asns")]
+	assert.IsType(t, json.RawMessage{}, asns)
+}
+
+func TestFlatten_ASNIxsInfo(t *testing.T) {
+	info := decodeFixture[bgpview.ASNIxsInfo](t, "asn-ixs.json")
+
+	table, err := render.Flatten(info)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ix_id", "name", "name_full", "country_code", "city", "ipv4_address", "ipv6_address", "speed"}, table.Columns)
+	assert.Len(t, table.Rows, len(info.Data))
+}
+
+func TestFlatten_values(t *testing.T) {
+	type item struct {
+		Name    string `json:"name"`
+		Skipped string `json:"-"`
+		Path    bgpview.ASPath
+		Nested  *struct {
+			Value float64 `json:"value"`
+		} `json:"nested"`
+	}
+
+	table, err := render.Flatten([]item{
+		{Name: "a", Path: bgpview.ASPath{{ASNs: []int{1, 2}}}},
+		{Name: "b", Nested: &struct {
+			Value float64 `json:"value"`
+		}{Value: 1.5}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"name", "Path", "nested.value"}, table.Columns)
+	assert.Equal(t, [][]interface{}{
+		{"a", "1 2", nil},
+		{"b", nil, 1.5},
+	}, table.Rows)
+}
+
+func TestFlatten_nil(t *testing.T) {
+	type item struct {
+		Name    string               `json:"name"`
+		Members []bgpview.MemberData `json:"members"`
+		Labels  map[string]string    `json:"labels"`
+	}
+
+	table, err := render.Flatten([]item{
+		{Name: "a"},
+		{Name: "b", Members: []bgpview.MemberData{{ASN: 1}}, Labels: map[string]string{"k": "v"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"name", "members", "labels"}, table.Columns)
+	assert.Equal(t, []interface{}{"a", nil, nil}, table.Rows[0])
+	assert.NotNil(t, table.Rows[1][1])
+	assert.NotNil(t, table.Rows[1][2])
+}
+
+func TestFlatten_errors(t *testing.T) {
+	var info *bgpview.ASNInfo
+
+	_, err := render.Flatten(info)
+	require.Error(t, err)
+
+	_, err = render.Flatten([]string{"a"})
+	require.Error(t, err)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,124 @@
+package bgpview
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for each following retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay (Retry-After is not capped).
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of the backoff delay that is randomized.
+	Jitter float64
+	// RetryableStatuses are the HTTP status codes that trigger a retry.
+	RetryableStatuses []int
+	// RetryableError reports whether a transport error triggers a retry.
+	// If nil, network errors and unexpected EOFs are retried.
+	RetryableError func(err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for the BGPView rate limits.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, status := range p.RetryableStatuses {
+			if apiErr.HTTPStatus == status {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+
+	return isNetworkError(err)
+}
+
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+
+	backoff := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && backoff > float64(p.MaxDelay) {
+		backoff = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		//nolint:gosec // jitter doesn't need a cryptographic random.
+		backoff -= backoff * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses the Retry-After header value (delay-seconds or HTTP-date).
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
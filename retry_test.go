@@ -0,0 +1,148 @@
+package bgpview
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return policy
+}
+
+func TestClient_retry(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, req *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			rw.Header().Set("Retry-After", "1")
+			rw.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			rw.WriteHeader(http.StatusBadGateway)
+		default:
+			testHandler("asn.json")(rw, req)
+		}
+	})
+
+	start := time.Now()
+
+	details, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, 61138, details.Data.ASN)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After must be honored")
+}
+
+func TestClient_retry_exhausted(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.ErrorIs(t, err, ErrServer)
+
+	assert.EqualValues(t, client.RetryPolicy.MaxAttempts, atomic.LoadInt32(&calls))
+}
+
+func TestClient_retry_notRetryable(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_retry_networkError(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, err := rw.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+
+			_ = conn.Close()
+
+			return
+		}
+
+		testHandler("asn.json")(rw, req)
+	})
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestClient_retry_contextCanceled(t *testing.T) {
+	client, mux := setupTest(t)
+	client.RetryPolicy = testRetryPolicy()
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Retry-After", "3600")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetASN(ctx, 61138)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, time.Minute, parseRetryAfter(date), float64(2*time.Second))
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3, nil))
+	assert.Equal(t, time.Second, policy.delay(10, nil))
+
+	policy.Jitter = 0.5
+
+	for i := 0; i < 100; i++ {
+		d := policy.delay(1, nil)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 100*time.Millisecond)
+	}
+}
@@ -0,0 +1,240 @@
+package bgpview
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaDriftError reports the differences between a response payload and the type used to decode it.
+type SchemaDriftError struct {
+	// Endpoint is the name of the endpoint (e.g. "asn/prefixes").
+	Endpoint string
+	// URL is the requested URL.
+	URL string
+	// Unknown are the paths of the payload fields not modeled by the type (e.g. "data.ipv4_prefixes[].parent.foo").
+	Unknown []string
+	// Missing are the paths of the fields returned by every known payload of the endpoint, absent from the payload.
+	Missing []string
+}
+
+func (e *SchemaDriftError) Error() string {
+	var parts []string
+
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown fields: "+strings.Join(e.Unknown, ", "))
+	}
+
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing fields: "+strings.Join(e.Missing, ", "))
+	}
+
+	return fmt.Sprintf("bgpview: schema drift on %s: %s", e.Endpoint, strings.Join(parts, "; "))
+}
+
+func (e *SchemaDriftError) empty() bool {
+	return len(e.Unknown) == 0 && len(e.Missing) == 0
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// detectSchemaDrift compares the fields of a JSON payload with the fields of a type,
+// and with the fields usually returned by the endpoint (see schemaBaseline).
+func detectSchemaDrift(body []byte, typ reflect.Type, endpoint string) (unknown, missing []string, err error) {
+	diff, err := walkPayload(body, typ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sortedKeys(diff.unknown), diff.missingFrom(schemaBaseline[endpoint]), nil
+}
+
+// walkPayload walks a JSON payload along a type.
+func walkPayload(body []byte, typ reflect.Type) (*schemaDiff, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var payload interface{}
+
+	err := decoder.Decode(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &schemaDiff{
+		unknown: make(map[string]struct{}),
+		present: make(map[string]struct{}),
+		objects: make(map[string]struct{}),
+	}
+	diff.compare(payload, typ, "")
+
+	return diff, nil
+}
+
+type schemaDiff struct {
+	// unknown are the paths of the fields not modeled by the type.
+	unknown map[string]struct{}
+	// present are the paths of the modeled fields, in at least one object.
+	present map[string]struct{}
+	// objects are the paths of the non-null objects.
+	objects map[string]struct{}
+}
+
+func (d *schemaDiff) compare(value interface{}, typ reflect.Type, path string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if value == nil || isLeafType(typ) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		d.objects[path] = struct{}{}
+
+		fields := jsonFields(typ)
+
+		for key, v := range object {
+			field, ok := fields[key]
+			if !ok {
+				d.unknown[joinPath(path, key)] = struct{}{}
+				continue
+			}
+
+			d.present[joinPath(path, key)] = struct{}{}
+			d.compare(v, field.Type, joinPath(path, key))
+		}
+
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+
+		for _, item := range items {
+			d.compare(item, typ.Elem(), path+"[]")
+		}
+
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		for _, v := range object {
+			d.compare(v, typ.Elem(), path+".*")
+		}
+
+	default:
+	}
+}
+
+// missingFrom returns the expected paths absent from the payload.
+// A path is expected only when its parent object is in the payload:
+// the fields of a null object or of the items of an empty list are not missing.
+func (d *schemaDiff) missingFrom(expected []string) []string {
+	missing := make(map[string]struct{})
+
+	for _, path := range expected {
+		if _, ok := d.present[path]; ok {
+			continue
+		}
+
+		if _, ok := d.objects[parentPath(path)]; ok {
+			missing[path] = struct{}{}
+		}
+	}
+
+	return sortedKeys(missing)
+}
+
+func isLeafType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Interface {
+		return true
+	}
+
+	ptr := reflect.PointerTo(typ)
+
+	return ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+// jsonFields returns the fields of a struct type by JSON name.
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for key, f := range jsonFields(field.Type) {
+				fields[key] = f
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
+
+// parentPath returns the path of the object holding a field (e.g. "data.prefixes[]" for "data.prefixes[].asn").
+func parentPath(path string) string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return ""
+	}
+
+	return path[:i]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,313 @@
+package bgpview
+
+// schemaBaseline are the paths of the fields returned by every fixture of an endpoint,
+// i.e. the fields expected in the payloads of the endpoint (see SchemaDriftError.Missing).
+//
+// The response types are shared by payloads of different shapes (e.g. PrefixData in ASNPrefixesInfo and IPInfo),
+// so the expected fields can't be derived from the types.
+// Test_schemaBaseline checks the table against bgpviewtest/fixtures.
+var schemaBaseline = map[string][]string{
+	"asn": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.abuse_contacts",
+		"data.asn",
+		"data.country_code",
+		"data.date_updated",
+		"data.description_full",
+		"data.description_short",
+		"data.email_contacts",
+		"data.iana_assignment",
+		"data.iana_assignment.assignment_status",
+		"data.iana_assignment.date_assigned",
+		"data.iana_assignment.description",
+		"data.iana_assignment.whois_server",
+		"data.looking_glass",
+		"data.name",
+		"data.owner_address",
+		"data.rir_allocation",
+		"data.rir_allocation.allocation_status",
+		"data.rir_allocation.country_code",
+		"data.rir_allocation.date_allocated",
+		"data.rir_allocation.rir_name",
+		"data.traffic_estimation",
+		"data.traffic_ratio",
+		"data.website",
+		"status",
+		"status_message",
+	},
+	"asn/downstreams": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.ipv4_downstreams",
+		"data.ipv6_downstreams",
+		"data.ipv6_downstreams[].asn",
+		"data.ipv6_downstreams[].country_code",
+		"data.ipv6_downstreams[].description",
+		"data.ipv6_downstreams[].name",
+		"status",
+		"status_message",
+	},
+	"asn/ixs": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data[].city",
+		"data[].country_code",
+		"data[].ipv4_address",
+		"data[].ipv6_address",
+		"data[].ix_id",
+		"data[].name",
+		"data[].name_full",
+		"data[].speed",
+		"status",
+		"status_message",
+	},
+	"asn/peers": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.ipv4_peers",
+		"data.ipv4_peers[].asn",
+		"data.ipv4_peers[].country_code",
+		"data.ipv4_peers[].description",
+		"data.ipv4_peers[].name",
+		"data.ipv6_peers",
+		"data.ipv6_peers[].asn",
+		"data.ipv6_peers[].country_code",
+		"data.ipv6_peers[].description",
+		"data.ipv6_peers[].name",
+		"status",
+		"status_message",
+	},
+	"asn/prefixes": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.ipv4_prefixes",
+		"data.ipv4_prefixes[].cidr",
+		"data.ipv4_prefixes[].country_code",
+		"data.ipv4_prefixes[].description",
+		"data.ipv4_prefixes[].ip",
+		"data.ipv4_prefixes[].name",
+		"data.ipv4_prefixes[].parent",
+		"data.ipv4_prefixes[].parent.allocation_status",
+		"data.ipv4_prefixes[].parent.cidr",
+		"data.ipv4_prefixes[].parent.ip",
+		"data.ipv4_prefixes[].parent.prefix",
+		"data.ipv4_prefixes[].parent.rir_name",
+		"data.ipv4_prefixes[].prefix",
+		"data.ipv4_prefixes[].roa_status",
+		"data.ipv6_prefixes",
+		"data.ipv6_prefixes[].cidr",
+		"data.ipv6_prefixes[].country_code",
+		"data.ipv6_prefixes[].description",
+		"data.ipv6_prefixes[].ip",
+		"data.ipv6_prefixes[].name",
+		"data.ipv6_prefixes[].parent",
+		"data.ipv6_prefixes[].parent.allocation_status",
+		"data.ipv6_prefixes[].parent.cidr",
+		"data.ipv6_prefixes[].parent.ip",
+		"data.ipv6_prefixes[].parent.prefix",
+		"data.ipv6_prefixes[].parent.rir_name",
+		"data.ipv6_prefixes[].prefix",
+		"data.ipv6_prefixes[].roa_status",
+		"status",
+		"status_message",
+	},
+	"asn/upstreams": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.combined_graph",
+		"data.ipv4_graph",
+		"data.ipv4_upstreams",
+		"data.ipv4_upstreams[].asn",
+		"data.ipv4_upstreams[].country_code",
+		"data.ipv4_upstreams[].description",
+		"data.ipv4_upstreams[].name",
+		"data.ipv6_graph",
+		"data.ipv6_upstreams",
+		"data.ipv6_upstreams[].asn",
+		"data.ipv6_upstreams[].country_code",
+		"data.ipv6_upstreams[].description",
+		"data.ipv6_upstreams[].name",
+		"status",
+		"status_message",
+	},
+	"ip": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.iana_assignment",
+		"data.iana_assignment.assignment_status",
+		"data.iana_assignment.date_assigned",
+		"data.iana_assignment.description",
+		"data.iana_assignment.whois_server",
+		"data.ip",
+		"data.maxmind",
+		"data.maxmind.city",
+		"data.maxmind.country_code",
+		"data.prefixes",
+		"data.prefixes[].asn",
+		"data.prefixes[].asn.asn",
+		"data.prefixes[].asn.country_code",
+		"data.prefixes[].asn.description",
+		"data.prefixes[].asn.name",
+		"data.prefixes[].cidr",
+		"data.prefixes[].country_code",
+		"data.prefixes[].description",
+		"data.prefixes[].ip",
+		"data.prefixes[].name",
+		"data.prefixes[].prefix",
+		"data.ptr_record",
+		"data.rir_allocation",
+		"data.rir_allocation.allocation_status",
+		"data.rir_allocation.cidr",
+		"data.rir_allocation.country_code",
+		"data.rir_allocation.date_allocated",
+		"data.rir_allocation.ip",
+		"data.rir_allocation.prefix",
+		"data.rir_allocation.rir_name",
+		"status",
+		"status_message",
+	},
+	"ix": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.city",
+		"data.country_code",
+		"data.members",
+		"data.members[].asn",
+		"data.members[].country_code",
+		"data.members[].description",
+		"data.members[].ipv4_address",
+		"data.members[].ipv6_address",
+		"data.members[].name",
+		"data.members[].speed",
+		"data.members_count",
+		"data.name",
+		"data.name_full",
+		"data.policy_email",
+		"data.policy_phone",
+		"data.tech_email",
+		"data.tech_phone",
+		"data.url_stats",
+		"data.website",
+		"status",
+		"status_message",
+	},
+	"prefix": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.abuse_contacts",
+		"data.asns",
+		"data.asns[].asn",
+		"data.asns[].country_code",
+		"data.asns[].description",
+		"data.asns[].name",
+		"data.asns[].prefix_upstreams",
+		"data.asns[].prefix_upstreams[].asn",
+		"data.asns[].prefix_upstreams[].country_code",
+		"data.asns[].prefix_upstreams[].description",
+		"data.asns[].prefix_upstreams[].name",
+		"data.cidr",
+		"data.country_codes",
+		"data.country_codes.maxmind_country_code",
+		"data.country_codes.rir_allocation_country_code",
+		"data.country_codes.whois_country_code",
+		"data.date_updated",
+		"data.description_full",
+		"data.description_short",
+		"data.email_contacts",
+		"data.iana_assignment",
+		"data.iana_assignment.assignment_status",
+		"data.iana_assignment.date_assigned",
+		"data.iana_assignment.description",
+		"data.iana_assignment.whois_server",
+		"data.ip",
+		"data.maxmind",
+		"data.maxmind.city",
+		"data.maxmind.country_code",
+		"data.name",
+		"data.owner_address",
+		"data.prefix",
+		"data.related_prefixes",
+		"data.rir_allocation",
+		"data.rir_allocation.allocation_status",
+		"data.rir_allocation.cidr",
+		"data.rir_allocation.country_code",
+		"data.rir_allocation.date_allocated",
+		"data.rir_allocation.ip",
+		"data.rir_allocation.prefix",
+		"data.rir_allocation.rir_name",
+		"status",
+		"status_message",
+	},
+	"search": {
+		"@meta",
+		"@meta.api_version",
+		"@meta.execution_time",
+		"@meta.time_zone",
+		"data",
+		"data.asns",
+		"data.asns[].abuse_contacts",
+		"data.asns[].asn",
+		"data.asns[].country_code",
+		"data.asns[].description",
+		"data.asns[].email_contacts",
+		"data.asns[].name",
+		"data.asns[].rir_name",
+		"data.internet_exchanges",
+		"data.ipv4_prefixes",
+		"data.ipv4_prefixes[].abuse_contacts",
+		"data.ipv4_prefixes[].cidr",
+		"data.ipv4_prefixes[].country_code",
+		"data.ipv4_prefixes[].description",
+		"data.ipv4_prefixes[].email_contacts",
+		"data.ipv4_prefixes[].ip",
+		"data.ipv4_prefixes[].name",
+		"data.ipv4_prefixes[].parent_cidr",
+		"data.ipv4_prefixes[].parent_ip",
+		"data.ipv4_prefixes[].parent_prefix",
+		"data.ipv4_prefixes[].prefix",
+		"data.ipv4_prefixes[].rir_name",
+		"data.ipv6_prefixes",
+		"data.ipv6_prefixes[].abuse_contacts",
+		"data.ipv6_prefixes[].cidr",
+		"data.ipv6_prefixes[].country_code",
+		"data.ipv6_prefixes[].description",
+		"data.ipv6_prefixes[].email_contacts",
+		"data.ipv6_prefixes[].ip",
+		"data.ipv6_prefixes[].name",
+		"data.ipv6_prefixes[].parent_cidr",
+		"data.ipv6_prefixes[].parent_ip",
+		"data.ipv6_prefixes[].parent_prefix",
+		"data.ipv6_prefixes[].prefix",
+		"data.ipv6_prefixes[].rir_name",
+		"status",
+		"status_message",
+	},
+}
@@ -0,0 +1,296 @@
+package bgpview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const driftedASN = `{
+  "status": "ok",
+  "status_message": "Query was successful",
+  "data": {
+    "asn": 61138,
+    "name": "ZAPPIE-HOST-AS",
+    "new_field": true,
+    "rir_allocation": {"rir_name": "RIPE", "new_nested": 1}
+  },
+  "@meta": {"time_zone": "UTC", "api_version": 1, "execution_time": "1 ms"}
+}`
+
+func TestClient_strictDecoding(t *testing.T) {
+	client, mux := setupTest(t)
+	client.StrictDecoding = true
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(driftedASN))
+	})
+
+	_, err := client.GetASN(context.Background(), 61138)
+	require.Error(t, err)
+
+	var drift *SchemaDriftError
+	require.True(t, errors.As(err, &drift))
+
+	assert.Equal(t, "asn", drift.Endpoint)
+	assert.Equal(t, []string{"data.new_field", "data.rir_allocation.new_nested"}, drift.Unknown)
+	assert.Contains(t, drift.Missing, "data.description_short")
+	assert.Contains(t, drift.Missing, "data.rir_allocation.date_allocated")
+}
+
+func TestClient_schemaDriftHandler(t *testing.T) {
+	client, mux := setupTest(t)
+	client.StrictDecoding = true
+
+	var drifts []*SchemaDriftError
+
+	client.SchemaDriftHandler = func(err *SchemaDriftError) {
+		drifts = append(drifts, err)
+	}
+
+	mux.HandleFunc("/asn/61138", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(driftedASN))
+	})
+
+	details, err := client.GetASN(context.Background(), 61138)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ZAPPIE-HOST-AS", details.Data.Name)
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, []string{"data.new_field", "data.rir_allocation.new_nested"}, drifts[0].Unknown)
+}
+
+func TestClient_strictDecoding_fixtures(t *testing.T) {
+	client, mux := setupTest(t)
+	client.StrictDecoding = true
+
+	fixtures := map[string]string{
+		"/asn/61138":              "asn.json",
+		"/asn/61138/prefixes":     "asn-prefixes.json",
+		"/asn/61138/peers":        "asn-peers.json",
+		"/asn/61138/upstreams":    "asn-upstreams.json",
+		"/asn/61138/downstreams":  "asn-downstreams.json",
+		"/asn/61138/ixs":          "asn-ixs.json",
+		"/prefix/192.209.63.0/24": "prefix.json",
+		"/ip/2a05:dfc7:60::":      "ip.json",
+		"/ix/492":                 "ix.json",
+		"/search":                 "search.json",
+	}
+
+	for pattern, filename := range fixtures {
+		mux.HandleFunc(pattern, testHandler(filename))
+	}
+
+	ctx := context.Background()
+
+	_, err := client.GetASN(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetASNPrefixes(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetASNPeers(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetASNUpstreams(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetASNDownstreams(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetASNIxs(ctx, 61138)
+	require.NoError(t, err)
+	_, err = client.GetPrefix(ctx, "192.209.63.0", 24)
+	require.NoError(t, err)
+	_, err = client.GetIP(ctx, "2a05:dfc7:60::")
+	require.NoError(t, err)
+	_, err = client.GetIX(ctx, 492)
+	require.NoError(t, err)
+	_, err = client.GetSearch(ctx, "digitalocean")
+	require.NoError(t, err)
+}
+
+// schemaFixtures are the fixtures of the endpoints, with the request getting them.
+var schemaFixtures = map[string]struct {
+	endpoint string
+	get      func(ctx context.Context, client *Client) error
+}{
+	"asn.json": {"asn", func(ctx context.Context, client *Client) error { _, err := client.GetASN(ctx, 61138); return err }},
+	"asn-prefixes.json": {"asn/prefixes", func(ctx context.Context, client *Client) error {
+		_, err := client.GetASNPrefixes(ctx, 61138)
+		return err
+	}},
+	"asn-peers.json": {"asn/peers", func(ctx context.Context, client *Client) error { _, err := client.GetASNPeers(ctx, 61138); return err }},
+	"asn-upstreams.json": {"asn/upstreams", func(ctx context.Context, client *Client) error {
+		_, err := client.GetASNUpstreams(ctx, 61138)
+		return err
+	}},
+	"asn-upstreams-paths.json": {"asn/upstreams", func(ctx context.Context, client *Client) error {
+		_, err := client.GetASNUpstreams(ctx, 61138)
+		return err
+	}},
+	"asn-downstreams.json": {"asn/downstreams", func(ctx context.Context, client *Client) error {
+		_, err := client.GetASNDownstreams(ctx, 61138)
+		return err
+	}},
+	"asn-ixs.json": {"asn/ixs", func(ctx context.Context, client *Client) error { _, err := client.GetASNIxs(ctx, 61138); return err }},
+	"prefix.json": {"prefix", func(ctx context.Context, client *Client) error {
+		_, err := client.GetPrefix(ctx, "192.209.63.0", 24)
+		return err
+	}},
+	"prefix-maxmind.json": {"prefix", func(ctx context.Context, client *Client) error {
+		_, err := client.GetPrefix(ctx, "192.209.63.0", 24)
+		return err
+	}},
+	"ip.json": {"ip", func(ctx context.Context, client *Client) error {
+		_, err := client.GetIP(ctx, "2a05:dfc7:60::")
+		return err
+	}},
+	"ip-maxmind.json": {"ip", func(ctx context.Context, client *Client) error {
+		_, err := client.GetIP(ctx, "2a05:dfc7:60::")
+		return err
+	}},
+	"ix.json":           {"ix", func(ctx context.Context, client *Client) error { _, err := client.GetIX(ctx, 492); return err }},
+	"ix-url-stats.json": {"ix", func(ctx context.Context, client *Client) error { _, err := client.GetIX(ctx, 492); return err }},
+	"search.json": {"search", func(ctx context.Context, client *Client) error {
+		_, err := client.GetSearch(ctx, "digitalocean")
+		return err
+	}},
+}
+
+func TestClient_schemaDriftHandler_fixtures(t *testing.T) {
+	for filename, fixture := range schemaFixtures {
+		filename, fixture := filename, fixture
+		t.Run(filename, func(t *testing.T) {
+			t.Parallel()
+
+			client, mux := setupTest(t)
+			client.StrictDecoding = true
+
+			var drifts []*SchemaDriftError
+
+			client.SchemaDriftHandler = func(err *SchemaDriftError) {
+				drifts = append(drifts, err)
+			}
+
+			mux.HandleFunc("/", testHandler(filename))
+
+			err := fixture.get(context.Background(), client)
+			require.NoError(t, err)
+
+			assert.Empty(t, drifts)
+		})
+	}
+}
+
+func TestClient_schemaDriftHandler_missing(t *testing.T) {
+	client, mux := setupTest(t)
+	client.StrictDecoding = true
+
+	var drifts []*SchemaDriftError
+
+	client.SchemaDriftHandler = func(err *SchemaDriftError) {
+		drifts = append(drifts, err)
+	}
+
+	// The first prefix has no "asn" anymore.
+	body := strings.Replace(string(readFixture(t, "ip.json")), `"asn": {`, `"other": {`, 1)
+
+	mux.HandleFunc("/ip/2a05:dfc7:60::", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(body))
+	})
+
+	_, err := client.GetIP(context.Background(), "2a05:dfc7:60::")
+	require.NoError(t, err)
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "ip", drifts[0].Endpoint)
+	assert.Equal(t, []string{"data.prefixes[].other"}, drifts[0].Unknown)
+	assert.Equal(t, []string{"data.prefixes[].asn"}, drifts[0].Missing)
+}
+
+func Test_schemaBaseline(t *testing.T) {
+	baseline := make(map[string]map[string]struct{})
+
+	for filename, fixture := range schemaFixtures {
+		diff, err := walkPayload(readFixture(t, filename), reflect.TypeOf(schemaResponseTypes[fixture.endpoint]))
+		require.NoError(t, err, filename)
+
+		paths, ok := baseline[fixture.endpoint]
+		if !ok {
+			baseline[fixture.endpoint] = diff.present
+			continue
+		}
+
+		for path := range paths {
+			if _, ok := diff.present[path]; !ok {
+				delete(paths, path)
+			}
+		}
+	}
+
+	expected := make(map[string][]string)
+	for endpoint, paths := range baseline {
+		expected[endpoint] = sortedKeys(paths)
+	}
+
+	assert.Equal(t, expected, schemaBaseline)
+}
+
+// schemaResponseTypes are the response types of the endpoints.
+var schemaResponseTypes = map[string]interface{}{
+	"asn":             &ASNInfo{},
+	"asn/prefixes":    &ASNPrefixesInfo{},
+	"asn/peers":       &ASNPeersInfo{},
+	"asn/upstreams":   &ASNUpstreamsInfo{},
+	"asn/downstreams": &ASNDownstreamsInfo{},
+	"asn/ixs":         &ASNIxsInfo{},
+	"prefix":          &PrefixInfo{},
+	"ip":              &IPInfo{},
+	"ix":              &IXInfo{},
+	"search":          &SearchInfo{},
+}
+
+func Test_detectSchemaDrift(t *testing.T) {
+	type item struct {
+		A string `json:"a"`
+		B int    `json:"b,omitempty"`
+	}
+
+	type payload struct {
+		Items   []item          `json:"items"`
+		Raw     interface{}     `json:"raw"`
+		Ignored string          `json:"-"`
+		Nested  *item           `json:"nested"`
+		Map     map[string]item `json:"map"`
+	}
+
+	body := []byte(`{
+		"items": [{"a": "x", "c": 1}, {"a": "y", "b": 2}],
+		"raw": {"anything": true},
+		"nested": null,
+		"map": {"k": {"a": "z", "d": 1}},
+		"extra": 1
+	}`)
+
+	unknown, missing, err := detectSchemaDrift(body, reflect.TypeOf(&payload{}), "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"extra", "items[].c", "map.*.d"}, unknown)
+	assert.Empty(t, missing, "no baseline")
+
+	diff, err := walkPayload(body, reflect.TypeOf(&payload{}))
+	require.NoError(t, err)
+
+	// The fields of the null or absent objects are not missing.
+	expected := []string{"gone", "items", "items[].a", "items[].b", "items[].e", "map.*.a", "map.*.b", "nested.a", "absent.a"}
+	assert.Equal(t, []string{"gone", "items[].e", "map.*.b"}, diff.missingFrom(expected))
+}
+
+func TestSchemaDriftError_Error(t *testing.T) {
+	err := &SchemaDriftError{Endpoint: "ix", Unknown: []string{"data.a"}, Missing: []string{"data.b", "data.c"}}
+
+	assert.EqualError(t, err, "bgpview: schema drift on ix: unknown fields: data.a; missing fields: data.b, data.c")
+}
@@ -0,0 +1,89 @@
+package bgpview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimestampLayout is the layout of the BGPView timestamps (always UTC).
+const TimestampLayout = "2006-01-02 15:04:05"
+
+// Timestamp is a BGPView timestamp (e.g. "2015-03-04 00:00:00").
+// The zero value represents a null or empty timestamp.
+//
+// A value not matching TimestampLayout doesn't fail the decoding of the response:
+// the timestamp is zero (IsZero is true), and the value is kept in Raw.
+type Timestamp struct {
+	time.Time
+	// Raw is the value returned by the API when it isn't a valid timestamp, empty otherwise.
+	Raw string
+}
+
+// Err returns the error of parsing the value returned by the API, nil if it was valid, null or empty.
+func (t Timestamp) Err() error {
+	if t.Raw == "" {
+		return nil
+	}
+
+	_, err := time.ParseInLocation(TimestampLayout, t.Raw, time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		if t.Raw != "" {
+			return json.Marshal(t.Raw)
+		}
+
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.UTC().Format(TimestampLayout))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	*t = Timestamp{}
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var value string
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(TimestampLayout, value, time.UTC)
+	if err != nil {
+		t.Raw = value
+		return nil
+	}
+
+	t.Time = parsed
+
+	return nil
+}
+
+// String returns the timestamp in the BGPView layout, the invalid value returned by the API,
+// or an empty string for the zero value.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return t.Raw
+	}
+
+	return t.UTC().Format(TimestampLayout)
+}
@@ -0,0 +1,113 @@
+package bgpview
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected Timestamp
+	}{
+		{
+			desc:     "timestamp",
+			data:     `"2015-03-04 00:00:00"`,
+			expected: Timestamp{Time: time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			desc: "null",
+			data: `null`,
+		},
+		{
+			desc: "empty",
+			data: `""`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var ts Timestamp
+
+			err := json.Unmarshal([]byte(test.data), &ts)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ts)
+		})
+	}
+}
+
+func TestTimestamp_UnmarshalJSON_invalid(t *testing.T) {
+	var assignment IANAAssignment
+
+	// An invalid value doesn't fail the decoding, and is kept.
+	err := json.Unmarshal([]byte(`{"assignment_status": "allocated", "date_assigned": "2015-03-04T00:00:00Z"}`), &assignment)
+	require.NoError(t, err)
+
+	assert.Equal(t, AllocationStatusAllocated, assignment.AssignmentStatus)
+	assert.True(t, assignment.DateAssigned.IsZero())
+	assert.Equal(t, "2015-03-04T00:00:00Z", assignment.DateAssigned.Raw)
+	assert.Equal(t, "2015-03-04T00:00:00Z", assignment.DateAssigned.String())
+	require.Error(t, assignment.DateAssigned.Err())
+
+	data, err := json.Marshal(assignment)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"assignment_status": "allocated", "date_assigned": "2015-03-04T00:00:00Z"}`, string(data))
+
+	// A valid value resets the invalid one.
+	err = json.Unmarshal([]byte(`{"date_assigned": "2015-03-04 00:00:00"}`), &assignment)
+	require.NoError(t, err)
+
+	assert.Equal(t, Timestamp{Time: time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)}, assignment.DateAssigned)
+	require.NoError(t, assignment.DateAssigned.Err())
+
+	var ts Timestamp
+
+	err = json.Unmarshal([]byte(`1425427200`), &ts)
+	require.Error(t, err)
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(IANAAssignment{
+		AssignmentStatus: "allocated",
+		DateAssigned:     Timestamp{Time: time.Date(2015, 3, 4, 1, 0, 0, 0, time.FixedZone("CET", 3600))},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"assignment_status": "allocated", "date_assigned": "2015-03-04 00:00:00"}`, string(data))
+
+	data, err = json.Marshal(IANAAssignment{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"date_assigned": null}`, string(data))
+}
+
+func TestTimestamp_sort(t *testing.T) {
+	var allocations []AllocationData
+
+	err := json.Unmarshal([]byte(`[
+		{"prefix": "192.0.2.0/24", "date_allocated": "2015-04-28 00:00:00"},
+		{"prefix": "198.51.100.0/24", "date_allocated": null},
+		{"prefix": "203.0.113.0/24", "date_allocated": "2001-09-14 12:30:00"}
+	]`), &allocations)
+	require.NoError(t, err)
+
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].DateAllocated.Before(allocations[j].DateAllocated.Time)
+	})
+
+	assert.Equal(t, "198.51.100.0/24", allocations[0].Prefix)
+	assert.Equal(t, "203.0.113.0/24", allocations[1].Prefix)
+	assert.Equal(t, "192.0.2.0/24", allocations[2].Prefix)
+	assert.Equal(t, "2001-09-14 12:30:00", allocations[1].DateAllocated.String())
+}
@@ -1,7 +1,5 @@
 package bgpview
 
-import "encoding/json"
-
 type Meta struct {
 	TimeZone      string `json:"time_zone,omitempty"`
 	APIVersion    int    `json:"api_version,omitempty"`
@@ -26,10 +24,11 @@ type ASNData struct {
 	AbuseContacts     []string       `json:"abuse_contacts,omitempty"`
 	LookingGlass      string         `json:"looking_glass,omitempty"`
 	TrafficEstimation string         `json:"traffic_estimation,omitempty"`
-	TrafficRatio      string         `json:"traffic_ratio,omitempty"`
+	TrafficRatio      TrafficRatio   `json:"traffic_ratio,omitempty"`
 	OwnerAddress      []string       `json:"owner_address,omitempty"`
 	RIRAllocation     AllocationData `json:"rir_allocation,omitempty"`
-	DateUpdated       string         `json:"date_updated,omitempty"`
+	IANAAssignment    IANAAssignment `json:"iana_assignment,omitempty"`
+	DateUpdated       Timestamp      `json:"date_updated,omitempty"`
 }
 
 type ASNPrefixesInfo struct {
@@ -48,7 +47,7 @@ type ASNIPPrefixesData struct {
 	Prefix      string            `json:"prefix,omitempty"`
 	IP          string            `json:"ip,omitempty"`
 	CIDR        int               `json:"cidr,omitempty"`
-	RoaStatus   string            `json:"roa_status,omitempty"`
+	RoaStatus   ROAStatus         `json:"roa_status,omitempty"`
 	Name        string            `json:"name,omitempty"`
 	Description string            `json:"description,omitempty"`
 	CountryCode string            `json:"country_code,omitempty"`
@@ -56,10 +55,11 @@ type ASNIPPrefixesData struct {
 }
 
 type ASNPrefixesParent struct {
-	Prefix  string `json:"prefix,omitempty"`
-	IP      string `json:"ip,omitempty"`
-	CIDR    int    `json:"cidr,omitempty"`
-	RIRName string `json:"rir_name,omitempty"`
+	Prefix           string           `json:"prefix,omitempty"`
+	IP               string           `json:"ip,omitempty"`
+	CIDR             int              `json:"cidr,omitempty"`
+	RIRName          RIR              `json:"rir_name,omitempty"`
+	AllocationStatus AllocationStatus `json:"allocation_status,omitempty"`
 }
 
 type ASNPeersInfo struct {
@@ -91,14 +91,17 @@ type ASNUpstreamsInfo struct {
 type ASNUpstreamsData struct {
 	IPv4Upstreams []ASNIPUpstreamsData `json:"ipv4_upstreams,omitempty"`
 	IPv6Upstreams []ASNIPUpstreamsData `json:"ipv6_upstreams,omitempty"`
+	IPv4Graph     string               `json:"ipv4_graph,omitempty"`
+	IPv6Graph     string               `json:"ipv6_graph,omitempty"`
+	CombinedGraph string               `json:"combined_graph,omitempty"`
 }
 
 type ASNIPUpstreamsData struct {
-	ASN         int      `json:"asn,omitempty"`
-	Name        string   `json:"name,omitempty"`
-	Description string   `json:"description,omitempty"`
-	CountryCode string   `json:"country_code,omitempty"`
-	BgpPaths    []string `json:"bgp_paths,omitempty"`
+	ASN         int       `json:"asn,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CountryCode string    `json:"country_code,omitempty"`
+	BgpPaths    []BGPPath `json:"bgp_paths,omitempty"`
 }
 
 type ASNDownstreamsInfo struct {
@@ -109,11 +112,11 @@ type ASNDownstreamsInfo struct {
 }
 
 type ASNIPDownstreamsData struct {
-	ASN         int      `json:"asn,omitempty"`
-	Name        string   `json:"name,omitempty"`
-	Description string   `json:"description,omitempty"`
-	CountryCode string   `json:"country_code,omitempty"`
-	BgpPaths    []string `json:"bgp_paths,omitempty"`
+	ASN         int       `json:"asn,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CountryCode string    `json:"country_code,omitempty"`
+	BgpPaths    []BGPPath `json:"bgp_paths,omitempty"`
 }
 
 type ASNDownstreamsData struct {
@@ -133,6 +136,7 @@ type ASNIxsData struct {
 	Name        string `json:"name,omitempty"`
 	NameFull    string `json:"name_full,omitempty"`
 	CountryCode string `json:"country_code,omitempty"`
+	City        string `json:"city,omitempty"`
 	IPv4Address string `json:"ipv4_address,omitempty"`
 	IPv6Address string `json:"ipv6_address,omitempty"`
 	Speed       int    `json:"speed,omitempty"`
@@ -149,31 +153,44 @@ type PrefixData struct {
 	Prefix           string          `json:"prefix,omitempty"`
 	IP               string          `json:"ip,omitempty"`
 	CIDR             int             `json:"cidr,omitempty"`
+	ASN              ASN             `json:"asn,omitempty"`
 	ASNs             []ASN           `json:"asns,omitempty"`
 	Name             string          `json:"name,omitempty"`
+	Description      string          `json:"description,omitempty"`
 	DescriptionShort string          `json:"description_short,omitempty"`
 	DescriptionFull  []string        `json:"description_full,omitempty"`
+	CountryCode      string          `json:"country_code,omitempty"`
 	EmailContacts    []string        `json:"email_contacts,omitempty"`
 	AbuseContacts    []string        `json:"abuse_contacts,omitempty"`
 	OwnerAddress     []string        `json:"owner_address,omitempty"`
 	CountryCodes     CountryCodeData `json:"country_codes,omitempty"`
 	RIRAllocation    AllocationData  `json:"rir_allocation,omitempty"`
+	IANAAssignment   IANAAssignment  `json:"iana_assignment,omitempty"`
 	MaxMind          MaxMindData     `json:"maxmind,omitempty"`
-	DateUpdated      string          `json:"date_updated,omitempty"`
+	RelatedPrefixes  []PrefixData    `json:"related_prefixes,omitempty"`
+	DateUpdated      Timestamp       `json:"date_updated,omitempty"`
 }
 
 type MaxMindData struct {
 	CountryCode string      `json:"country_code,omitempty"`
-	City        interface{} `json:"city,omitempty"`
+	City        MaxMindCity `json:"city,omitempty"`
 }
 
 type AllocationData struct {
-	RIRName       string `json:"rir_name,omitempty"`
-	CountryCode   string `json:"country_code,omitempty"`
-	IP            string `json:"ip,omitempty"`
-	CIDR          int    `json:"cidr,omitempty"`
-	Prefix        string `json:"prefix,omitempty"`
-	DateAllocated string `json:"date_allocated,omitempty"`
+	RIRName          RIR              `json:"rir_name,omitempty"`
+	CountryCode      string           `json:"country_code,omitempty"`
+	IP               string           `json:"ip,omitempty"`
+	CIDR             FlexInt          `json:"cidr,omitempty"`
+	Prefix           string           `json:"prefix,omitempty"`
+	DateAllocated    Timestamp        `json:"date_allocated,omitempty"`
+	AllocationStatus AllocationStatus `json:"allocation_status,omitempty"`
+}
+
+type IANAAssignment struct {
+	AssignmentStatus AllocationStatus `json:"assignment_status,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	WhoisServer      string           `json:"whois_server,omitempty"`
+	DateAssigned     Timestamp        `json:"date_assigned,omitempty"`
 }
 
 type CountryCodeData struct {
@@ -183,10 +200,11 @@ type CountryCodeData struct {
 }
 
 type ASN struct {
-	ASN         int    `json:"asn,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
-	CountryCode string `json:"country_code,omitempty"`
+	ASN             int    `json:"asn,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	CountryCode     string `json:"country_code,omitempty"`
+	PrefixUpstreams []ASN  `json:"prefix_upstreams,omitempty"`
 }
 
 type IPInfo struct {
@@ -197,20 +215,19 @@ type IPInfo struct {
 }
 
 type IPData struct {
-	Prefixes        []PrefixData     `json:"prefixes,omitempty"`
-	RIRAllocation   IPAllocationData `json:"rir_allocation,omitempty"`
-	MaxMind         MaxMindData      `json:"maxmind,omitempty"`
-	RelatedPrefixes []PrefixData     `json:"related_prefixes,omitempty"`
+	IP              string         `json:"ip,omitempty"`
+	PTRRecord       string         `json:"ptr_record,omitempty"`
+	Prefixes        []PrefixData   `json:"prefixes,omitempty"`
+	RIRAllocation   AllocationData `json:"rir_allocation,omitempty"`
+	IANAAssignment  IANAAssignment `json:"iana_assignment,omitempty"`
+	MaxMind         MaxMindData    `json:"maxmind,omitempty"`
+	RelatedPrefixes []PrefixData   `json:"related_prefixes,omitempty"`
 }
 
-type IPAllocationData struct {
-	RIRName       string `json:"rir_name,omitempty"`
-	CountryCode   string `json:"country_code,omitempty"`
-	IP            string `json:"ip,omitempty"`
-	CIDR          string `json:"cidr,omitempty"`
-	Prefix        string `json:"prefix,omitempty"`
-	DateAllocated string `json:"date_allocated,omitempty"`
-}
+// IPAllocationData is the former name of AllocationData.
+//
+// Deprecated: use AllocationData.
+type IPAllocationData = AllocationData
 
 type IXInfo struct {
 	Status        string `json:"status,omitempty"`
@@ -220,18 +237,18 @@ type IXInfo struct {
 }
 
 type IXData struct {
-	Name         string          `json:"name,omitempty"`
-	NameFull     string          `json:"name_full,omitempty"`
-	Website      string          `json:"website,omitempty"`
-	TechEmail    string          `json:"tech_email,omitempty"`
-	TechPhone    string          `json:"tech_phone,omitempty"`
-	PolicyEmail  string          `json:"policy_email,omitempty"`
-	PolicyPhone  string          `json:"policy_phone,omitempty"`
-	City         string          `json:"city,omitempty"`
-	CountryCode  string          `json:"country_code,omitempty"`
-	URLStats     json.RawMessage `json:"url_stats,omitempty"`
-	MembersCount int             `json:"members_count,omitempty"`
-	Members      []MemberData    `json:"members,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	NameFull     string       `json:"name_full,omitempty"`
+	Website      string       `json:"website,omitempty"`
+	TechEmail    string       `json:"tech_email,omitempty"`
+	TechPhone    string       `json:"tech_phone,omitempty"`
+	PolicyEmail  string       `json:"policy_email,omitempty"`
+	PolicyPhone  string       `json:"policy_phone,omitempty"`
+	City         string       `json:"city,omitempty"`
+	CountryCode  string       `json:"country_code,omitempty"`
+	URLStats     URLStats     `json:"url_stats,omitempty"`
+	MembersCount int          `json:"members_count,omitempty"`
+	Members      []MemberData `json:"members,omitempty"`
 }
 
 type MemberData struct {
@@ -255,6 +272,7 @@ type SearchData struct {
 	ASNs         []SearchASNData        `json:"asns,omitempty"`
 	IPv4Prefixes []SearchIPPrefixesData `json:"ipv4_prefixes,omitempty"`
 	IPv6Prefixes []SearchIPPrefixesData `json:"ipv6_prefixes,omitempty"`
+	IXs          []SearchIXData         `json:"internet_exchanges,omitempty"`
 }
 
 type SearchASNData struct {
@@ -264,7 +282,7 @@ type SearchASNData struct {
 	CountryCode   string   `json:"country_code,omitempty"`
 	EmailContacts []string `json:"email_contacts,omitempty"`
 	AbuseContacts []string `json:"abuse_contacts,omitempty"`
-	RIRName       string   `json:"rir_name,omitempty"`
+	RIRName       RIR      `json:"rir_name,omitempty"`
 }
 
 type SearchIPPrefixesData struct {
@@ -276,8 +294,16 @@ type SearchIPPrefixesData struct {
 	Description   string   `json:"description,omitempty"`
 	EmailContacts []string `json:"email_contacts,omitempty"`
 	AbuseContacts []string `json:"abuse_contacts,omitempty"`
-	RIRName       string   `json:"rir_name,omitempty"`
+	RIRName       RIR      `json:"rir_name,omitempty"`
 	ParentPrefix  string   `json:"parent_prefix,omitempty"`
 	ParentIP      string   `json:"parent_ip,omitempty"`
 	ParentCIDR    int      `json:"parent_cidr,omitempty"`
 }
+
+type SearchIXData struct {
+	IxID        int    `json:"ix_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	NameFull    string `json:"name_full,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	City        string `json:"city,omitempty"`
+}